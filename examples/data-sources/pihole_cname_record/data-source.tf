@@ -0,0 +1,13 @@
+data "pihole_cname_record" "router" {
+  domain = "router.lab.example.com"
+}
+
+# Tolerate a missing record instead of failing
+data "pihole_cname_record" "optional" {
+  domain        = "nas.lab.example.com"
+  allow_missing = true
+}
+
+output "nas_target" {
+  value = data.pihole_cname_record.optional.found ? data.pihole_cname_record.optional.target : null
+}
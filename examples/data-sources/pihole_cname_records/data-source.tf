@@ -7,3 +7,13 @@ data "pihole_cname_records" "records" {}
 data "pihole_cname_records" "records" {
     depends_on = [RESOURCE_IDENTIFIER]
 }
+
+# Only CNAME records pointing at the ingress controller
+data "pihole_cname_records" "ingress" {
+  target = "ingress.example.com"
+}
+
+# Domains pointing at the ingress controller
+locals {
+  ingress_aliases = one(data.pihole_cname_records.ingress.by_target).domains
+}
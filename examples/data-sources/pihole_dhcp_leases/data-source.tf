@@ -0,0 +1,12 @@
+data "pihole_dhcp_leases" "printers" {
+  hostname_regex = "^printer-"
+  ip_cidr        = "192.168.1.0/24"
+}
+
+# Publish a local DNS record for every printer holding a lease
+resource "pihole_dns_record" "printer" {
+  for_each = { for lease in data.pihole_dhcp_leases.printers.leases : lease.hostname => lease.ip }
+
+  domain = "${each.key}.lan.example.com"
+  ip     = each.value
+}
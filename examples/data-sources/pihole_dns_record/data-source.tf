@@ -0,0 +1,13 @@
+data "pihole_dns_record" "router" {
+  domain = "router.lab.example.com"
+}
+
+# Tolerate a missing record instead of failing
+data "pihole_dns_record" "optional" {
+  domain        = "nas.lab.example.com"
+  allow_missing = true
+}
+
+output "nas_ip" {
+  value = data.pihole_dns_record.optional.found ? data.pihole_dns_record.optional.ip : null
+}
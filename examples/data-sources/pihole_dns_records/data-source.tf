@@ -1 +1,12 @@
 data "pihole_dns_records" "records" {}
+
+# Only local DNS records of lab.example.com and its subdomains within 10.0.0.0/8
+data "pihole_dns_records" "lab" {
+  domain_suffix = "lab.example.com"
+  ip_cidr       = "10.0.0.0/8"
+}
+
+# Feed the records into other modules as a map of domains to their addresses
+locals {
+  lab_ips = { for entry in data.pihole_dns_records.lab.by_domain : entry.domain => entry.ips }
+}
@@ -0,0 +1,18 @@
+data "pihole_domain_search" "ads" {
+  domain = "ads.example.com"
+}
+
+locals {
+  # Groups with an enabled deny entry or blocklist matching the domain
+  ads_blocked_for = distinct(flatten(concat(
+    [for d in data.pihole_domain_search.ads.domains : d.group_names if d.enabled && d.type == "deny"],
+    [for a in data.pihole_domain_search.ads.adlists : a.group_names if a.enabled && a.type == "block"],
+  )))
+}
+
+check "ads_blocked_for_kids_only" {
+  assert {
+    condition     = local.ads_blocked_for == ["kids"]
+    error_message = "ads.example.com is blocked for ${jsonencode(local.ads_blocked_for)}, expected the kids group only."
+  }
+}
@@ -0,0 +1,18 @@
+data "pihole_info" "this" {}
+
+resource "pihole_dns_record" "record" {
+  domain = "foo.lab.example.com"
+  ip     = "192.168.1.10"
+  ttl    = 300
+
+  lifecycle {
+    precondition {
+      condition     = tonumber(split(".", trimprefix(data.pihole_info.this.ftl_version, "v"))[0]) >= 6
+      error_message = "Pi-hole FTL 6.0 or later is required, found ${data.pihole_info.this.ftl_version}."
+    }
+  }
+}
+
+output "pihole_update_available" {
+  value = data.pihole_info.this.update_available
+}
@@ -0,0 +1,10 @@
+data "pihole_messages" "regex" {
+  type = "REGEX"
+}
+
+check "no_regex_errors" {
+  assert {
+    condition     = length(data.pihole_messages.regex.messages) == 0
+    error_message = "Pi-hole reports invalid regular expressions: ${join("; ", data.pihole_messages.regex.messages[*].message)}"
+  }
+}
@@ -0,0 +1,17 @@
+data "pihole_network_devices" "recent" {
+  last_seen_within = "24h"
+  interface        = "eth0"
+}
+
+locals {
+  known_macs = toset(["aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02"])
+
+  unknown_devices = [for device in data.pihole_network_devices.recent.devices : device if !contains(local.known_macs, device.mac)]
+}
+
+check "no_unknown_devices" {
+  assert {
+    condition     = length(local.unknown_devices) == 0
+    error_message = "Unknown devices on the network: ${join(", ", [for device in local.unknown_devices : "${device.mac} (${device.vendor})"])}"
+  }
+}
@@ -0,0 +1,5 @@
+data "pihole_network_gateway" "host" {}
+
+output "uplink" {
+  value = "${data.pihole_network_gateway.host.interface} via ${data.pihole_network_gateway.host.address}"
+}
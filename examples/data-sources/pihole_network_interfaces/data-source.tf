@@ -0,0 +1,14 @@
+data "pihole_network_interfaces" "host" {}
+
+locals {
+  # Global IPv4 addresses of the interfaces that are up, by interface name
+  listen_addresses = {
+    for iface in data.pihole_network_interfaces.host.interfaces : iface.name => [
+      for address in iface.addresses : address.address if address.family == "inet" && address.scope == "global"
+    ] if iface.state == "up" && iface.type != "loopback"
+  }
+}
+
+output "listen_addresses" {
+  value = local.listen_addresses
+}
@@ -0,0 +1,5 @@
+data "pihole_network_routes" "host" {}
+
+output "connected_networks" {
+  value = [for route in data.pihole_network_routes.host.routes : route.destination if route.family == "inet" && route.scope == "link"]
+}
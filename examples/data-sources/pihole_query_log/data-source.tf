@@ -0,0 +1,24 @@
+# Why can the laptop not resolve the intranet?
+data "pihole_query_log" "laptop" {
+  client      = "192.168.1.42"
+  domain      = "*.intranet.example.com"
+  from        = "2024-01-01T08:00:00Z"
+  until       = "2024-01-01T09:00:00Z"
+  max_results = 50
+}
+
+# Recent queries blocked by gravity
+data "pihole_query_log" "gravity" {
+  status      = "GRAVITY"
+  max_results = 20
+}
+
+# Recent queries blocked for any reason, e.g. by regex or denylist entries or an upstream server
+data "pihole_query_log" "blocked" {
+  blocked     = true
+  max_results = 20
+}
+
+output "laptop_replies" {
+  value = [for q in data.pihole_query_log.laptop.queries : "${q.timestamp} ${q.type} ${q.domain}: ${q.status} ${q.reply_type}"]
+}
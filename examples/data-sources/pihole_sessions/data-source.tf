@@ -0,0 +1,5 @@
+data "pihole_sessions" "all" {}
+
+output "automation_sessions" {
+  value = [for s in data.pihole_sessions.all.sessions : "${s.remote_address} ${s.user_agent} (since ${s.login_at})" if !s.current_session]
+}
@@ -0,0 +1,12 @@
+data "pihole_stats_summary" "this" {}
+
+output "pihole_percent_blocked" {
+  value = data.pihole_stats_summary.this.percent_blocked
+}
+
+check "gravity_fresh" {
+  assert {
+    condition     = timecmp(timeadd(data.pihole_stats_summary.this.gravity_last_update, "168h"), plantimestamp()) > 0
+    error_message = "Gravity has not been updated for more than a week."
+  }
+}
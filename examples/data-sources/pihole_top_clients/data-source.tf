@@ -0,0 +1,15 @@
+data "pihole_top_clients" "busiest" {
+  limit = 10
+}
+
+# Clients with the most blocked queries of a month, read from the long-term database
+data "pihole_top_clients" "blocked" {
+  limit   = 10
+  blocked = true
+  from    = "2024-01-01T00:00:00Z"
+  until   = "2024-02-01T00:00:00Z"
+}
+
+output "busiest_clients" {
+  value = [for c in data.pihole_top_clients.busiest.clients : coalesce(c.name, c.ip)]
+}
@@ -0,0 +1,16 @@
+# Top blocked domains over the last 24 hours
+data "pihole_top_domains" "blocked" {
+  limit   = 25
+  blocked = true
+}
+
+# Top permitted domains of a month, read from the long-term database
+data "pihole_top_domains" "january" {
+  limit = 50
+  from  = "2024-01-01T00:00:00Z"
+  until = "2024-02-01T00:00:00Z"
+}
+
+output "blocked_domain_counts" {
+  value = { for d in data.pihole_top_domains.blocked.domains : d.domain => d.count }
+}
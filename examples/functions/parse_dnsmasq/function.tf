@@ -0,0 +1,20 @@
+# Migrate dnsmasq address=, host-record= and cname= lines to Pi-hole records
+locals {
+  dnsmasq = provider::pihole::parse_dnsmasq(file("${path.module}/02-lan.conf"))
+}
+
+resource "pihole_dns_record" "dnsmasq" {
+  for_each = { for record in local.dnsmasq.dns_records : record.domain => record }
+
+  domain      = each.value.domain
+  ip          = each.value.ip
+  host_record = each.value.ttl > 0
+  ttl         = each.value.ttl
+}
+
+resource "pihole_cname_record" "dnsmasq" {
+  for_each = { for record in local.dnsmasq.cname_records : record.domain => record }
+
+  domain = each.value.domain
+  target = each.value.target
+}
@@ -0,0 +1,15 @@
+# Migrate an existing hosts file to Pi-hole local DNS records
+locals {
+  hosts = {
+    for record in provider::pihole::parse_hosts(file("${path.module}/hosts")) :
+    record.domain => record if record.domain != "localhost"
+  }
+}
+
+resource "pihole_dns_record" "hosts" {
+  for_each = local.hosts
+
+  domain  = each.value.domain
+  ip      = each.value.ip
+  comment = each.value.comment
+}
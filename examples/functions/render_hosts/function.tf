@@ -0,0 +1,7 @@
+# Export the Pi-hole local DNS records as a hosts file
+data "pihole_dns_records" "records" {}
+
+resource "local_file" "hosts" {
+  filename = "${path.module}/hosts"
+  content  = provider::pihole::render_hosts(data.pihole_dns_records.records.records)
+}
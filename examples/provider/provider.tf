@@ -24,3 +24,32 @@ provider "pihole" {
   # Pi-hole sets the API token to the admin password hashed twiced via SHA-256
   api_token = sha256(sha256(var.pihole_password))
 }
+
+provider "pihole" {
+  url      = "https://pihole.domain.com"
+  password = var.pihole_password
+
+  # Reuse sessions across runs to avoid exhausting Pi-hole's concurrent session limit
+  session_cache = true # PIHOLE_SESSION_CACHE
+}
+
+provider "pihole" {
+  url          = "https://pihole.domain.com"
+  app_password = var.pihole_app_password # PIHOLE_APP_PASSWORD
+}
+
+provider "pihole" {
+  url      = "https://pihole.domain.com"
+  password = var.pihole_password
+
+  # Required when two-factor authentication is enabled for the admin account
+  totp_secret = var.pihole_totp_secret # PIHOLE_TOTP_SECRET
+}
+
+provider "pihole" {
+  url      = "https://pihole.domain.com"
+  password = var.pihole_password
+
+  # Comment written to records which do not set one
+  default_comment = "managed by terraform ({{ .Workspace }})" # PIHOLE_DEFAULT_COMMENT
+}
@@ -0,0 +1,10 @@
+resource "pihole_app_password" "home_assistant" {
+  rotation_trigger = {
+    rotated_at = "2024-06-01"
+  }
+}
+
+output "home_assistant_password" {
+  value     = pihole_app_password.home_assistant.password
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+# Force the camera to request a new lease after its reservation changed
+resource "pihole_dhcp_lease_revocation" "camera" {
+  ip = "192.168.1.50"
+
+  triggers = {
+    reservation = "aa:bb:cc:dd:ee:ff,192.168.1.60,camera"
+  }
+}
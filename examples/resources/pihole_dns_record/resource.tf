@@ -1,4 +1,13 @@
 resource "pihole_dns_record" "record" {
-  domain = "foo.com"
-  ip     = "127.0.0.1"
+  domain  = "foo.com"
+  ip      = "127.0.0.1"
+  comment = "managed by terraform - ticket NET-123"
+}
+
+# Records with a TTL are stored as dnsmasq host-record lines
+resource "pihole_dns_record" "failover" {
+  domain      = "app.example.com"
+  ip          = "10.0.0.10"
+  host_record = true
+  ttl         = 30
 }
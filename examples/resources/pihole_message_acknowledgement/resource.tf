@@ -0,0 +1,8 @@
+data "pihole_messages" "load" {
+  type = "LOAD"
+}
+
+# Clear the load warnings from the dashboard once they have been looked at
+resource "pihole_message_acknowledgement" "load" {
+  ids = data.pihole_messages.load.ids
+}
@@ -0,0 +1,9 @@
+# Revoke sessions leaked by earlier provider runs that are more than an hour old
+resource "pihole_session_cleanup" "leaked" {
+  user_agent_regex = "terraform-provider-pihole/"
+  older_than       = "1h"
+
+  triggers = {
+    run = timestamp()
+  }
+}
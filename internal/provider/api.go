@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// apiClient performs requests against Pi-hole API endpoints that are not covered by lib-pihole-go
+type apiClient struct {
+	// The Pi-hole URL
+	baseURL string
+
+	// HTTP client shared with lib-pihole-go
+	http *http.Client
+
+	// Headers sent with every request
+	headers http.Header
+
+	// Session ID sent via the X-FTL-SID header, if present
+	sessionID string
+}
+
+// withSession returns a copy of the API client authenticated with the passed session ID
+func (c *apiClient) withSession(sessionID string) *apiClient {
+	clone := *c
+	clone.sessionID = sessionID
+
+	return &clone
+}
+
+// do sends a request to the Pi-hole API, encoding body as JSON and decoding the response into out when non-nil
+func (c *apiClient) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.sessionID != "" {
+		req.Header.Set("X-FTL-SID", c.sessionID)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newAPIError(method, path, res.StatusCode, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
+
+// apiError is returned when Pi-hole responds with an unsuccessful status code
+type apiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+
+	// Key, Message and Hint are parsed from the Pi-hole error payload, when present
+	Key     string
+	Message string
+	Hint    string
+
+	// body is the raw response body, for endpoints such as /api/auth that describe failures outside of the error payload
+	body []byte
+}
+
+// newAPIError parses a Pi-hole error payload of the form {"error":{"key":"...","message":"...","hint":"..."}}
+func newAPIError(method string, path string, statusCode int, body []byte) *apiError {
+	err := &apiError{Method: method, Path: path, StatusCode: statusCode, body: body}
+
+	var payload struct {
+		Error struct {
+			Key     string `json:"key"`
+			Message string `json:"message"`
+			Hint    string `json:"hint"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(body, &payload) == nil && payload.Error.Key != "" {
+		err.Key = payload.Error.Key
+		err.Message = payload.Error.Message
+		err.Hint = payload.Error.Hint
+	} else {
+		err.Message = strings.TrimSpace(string(body))
+	}
+
+	return err
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s %s returned status %d", e.Method, e.Path, e.StatusCode)
+	if e.Key != "" {
+		msg += fmt.Sprintf(" (%s)", e.Key)
+	}
+
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if e.Hint != "" {
+		msg += " - " + e.Hint
+	}
+
+	return msg
+}
+
+// isNotFoundError reports whether err is a Pi-hole API error with status 404
+func isNotFoundError(err error) bool {
+	var apiErr *apiError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// getConfig reads the Pi-hole configuration element at the passed dot separated path (e.g. "dns.hosts")
+func (c *apiClient) getConfig(ctx context.Context, element string, out interface{}) error {
+	return c.do(ctx, http.MethodGet, "/api/config/"+strings.ReplaceAll(element, ".", "/"), nil, out)
+}
+
+// patchConfig applies a partial update to the Pi-hole configuration
+func (c *apiClient) patchConfig(ctx context.Context, config map[string]interface{}) error {
+	return c.do(ctx, http.MethodPatch, "/api/config", map[string]interface{}{"config": config}, nil)
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound := newAPIError(http.MethodDelete, "/api/dhcp/leases/192.0.2.1", http.StatusNotFound, []byte(`{"error":{"key":"not_found","message":"Lease not found"}}`))
+
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"not found":         {notFound, true},
+		"wrapped not found": {fmt.Errorf("failed to revoke lease: %w", notFound), true},
+		"bad request":       {newAPIError(http.MethodDelete, "/api/dhcp/leases/foo", http.StatusBadRequest, nil), false},
+		"other error":       {errors.New("connection refused"), false},
+	}
+
+	for name, c := range cases {
+		if got := isNotFoundError(c.err); got != c.want {
+			t.Errorf("%s: isNotFoundError() = %t, want %t", name, got, c.want)
+		}
+	}
+}
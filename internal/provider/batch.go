@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+// dnsBatchOp is a pending change to the local DNS or CNAME configuration
+type dnsBatchOp struct {
+	// ctx is the context of the submitting resource operation
+	ctx context.Context
+
+	// apply mutates the configuration, an error only fails this operation
+	apply func(config *dnsConfig) error
+
+	result chan error
+}
+
+// dnsBatcher coalesces local DNS and CNAME changes submitted within a short window into a single configuration patch
+type dnsBatcher struct {
+	api    *apiClient
+	window time.Duration
+
+	// writeMutex is the client's configuration write lock, held while a batch is applied
+	writeMutex *sync.Mutex
+
+	// cache is invalidated once a batch has been written
+	cache *recordCache
+
+	mu      sync.Mutex
+	pending []*dnsBatchOp
+}
+
+// submit queues a change and waits until the batch containing it has been written to Pi-hole
+func (b *dnsBatcher) submit(ctx context.Context, apply func(config *dnsConfig) error) error {
+	op := &dnsBatchOp{ctx: ctx, apply: apply, result: make(chan error, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, op)
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case err := <-op.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush applies all pending changes with a single read and a single patch of the DNS configuration.
+// When the patch is rejected, the changes are retried one by one so the error is reported to the change causing it.
+func (b *dnsBatcher) flush() {
+	b.writeMutex.Lock()
+	defer b.writeMutex.Unlock()
+
+	// Changes submitted while waiting for the write lock are part of this batch
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	// Changes whose resource operation was cancelled in the meantime are dropped
+	ops := make([]*dnsBatchOp, 0, len(pending))
+	for _, op := range pending {
+		if err := op.ctx.Err(); err != nil {
+			op.result <- err
+			continue
+		}
+
+		ops = append(ops, op)
+	}
+
+	if len(ops) == 0 {
+		return
+	}
+
+	// The batch is written with the context of its first change, so it carries its deadline and logger
+	ctx := ops[0].ctx
+
+	applied, err := b.write(ctx, ops)
+	if err != nil && len(applied) > 1 {
+		for _, op := range applied {
+			retried, err := b.write(ctx, []*dnsBatchOp{op})
+			if len(retried) > 0 {
+				op.result <- err
+			}
+		}
+
+		return
+	}
+
+	for _, op := range applied {
+		op.result <- err
+	}
+}
+
+// write applies changes to the current DNS configuration and patches it, changes failing to apply receive their error directly.
+// It returns the changes that were applied together with the error of reading or writing the configuration.
+func (b *dnsBatcher) write(ctx context.Context, ops []*dnsBatchOp) ([]*dnsBatchOp, error) {
+	config, err := b.api.getDNSConfig(ctx)
+	if err != nil {
+		return ops, err
+	}
+
+	hosts := slices.Clone(config.Hosts)
+	cnames := slices.Clone(config.CNAMERecords)
+	lines := slices.Clone(config.DNSMasqLines)
+
+	applied := make([]*dnsBatchOp, 0, len(ops))
+	for _, op := range ops {
+		if err := op.apply(config); err != nil {
+			op.result <- err
+			continue
+		}
+
+		applied = append(applied, op)
+	}
+
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	dns := map[string]interface{}{}
+	if !slices.Equal(hosts, config.Hosts) {
+		dns["hosts"] = config.Hosts
+	}
+
+	if !slices.Equal(cnames, config.CNAMERecords) {
+		dns["cnameRecords"] = config.CNAMERecords
+	}
+
+	patch := map[string]interface{}{}
+	if len(dns) > 0 {
+		patch["dns"] = dns
+	}
+
+	if !slices.Equal(lines, config.DNSMasqLines) {
+		patch["misc"] = map[string]interface{}{"dnsmasq_lines": config.DNSMasqLines}
+	}
+
+	if len(patch) > 0 {
+		err = b.api.patchConfig(ctx, patch)
+		b.cache.invalidate()
+	}
+
+	return applied, err
+}
+
+// createDNSRecord adds a local DNS record as part of the next batch, as a host record when asHostRecord is set
+func (c *Client) createDNSRecord(ctx context.Context, record pihole.DNSRecord, asHostRecord bool) error {
+	return c.batcher.submit(ctx, func(config *dnsConfig) error {
+		return config.addDNSRecord(record, asHostRecord)
+	})
+}
+
+// updateDNSRecord replaces the local DNS record of a domain as part of the next batch, as a host record when asHostRecord is set
+func (c *Client) updateDNSRecord(ctx context.Context, record pihole.DNSRecord, asHostRecord bool) error {
+	return c.batcher.submit(ctx, func(config *dnsConfig) error {
+		config.removeDNSRecord(record.Domain)
+		return config.addDNSRecord(record, asHostRecord)
+	})
+}
+
+// deleteDNSRecord removes the local DNS records of a domain as part of the next batch
+func (c *Client) deleteDNSRecord(ctx context.Context, domain string) error {
+	return c.batcher.submit(ctx, func(config *dnsConfig) error {
+		config.removeDNSRecord(domain)
+		return nil
+	})
+}
+
+// createCNAMERecord adds a CNAME record as part of the next batch
+func (c *Client) createCNAMERecord(ctx context.Context, record pihole.CNAMERecord) error {
+	return c.batcher.submit(ctx, func(config *dnsConfig) error {
+		return config.addCNAMERecord(record)
+	})
+}
+
+// deleteCNAMERecord removes the CNAME record of a domain as part of the next batch
+func (c *Client) deleteCNAMERecord(ctx context.Context, domain string) error {
+	return c.batcher.submit(ctx, func(config *dnsConfig) error {
+		config.removeCNAMERecord(domain)
+		return nil
+	})
+}
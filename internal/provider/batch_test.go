@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testDNSConfigServer fakes the Pi-hole dns.hosts, dns.cnameRecords and misc.dnsmasq_lines configuration endpoints
+type testDNSConfigServer struct {
+	mu      sync.Mutex
+	config  dnsConfig
+	gets    int
+	patches int
+
+	// onGetHosts is called for every read of dns.hosts, if set
+	onGetHosts func()
+
+	// rejectHost fails patches of dns.hosts containing this entry with a Pi-hole bad_request error, if set
+	rejectHost string
+}
+
+// state returns the configuration and the number of patches received so far
+func (s *testDNSConfigServer) state() (dnsConfig, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config, s.patches
+}
+
+func (s *testDNSConfigServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/config/dns/hosts":
+		s.gets++
+		if s.onGetHosts != nil {
+			s.onGetHosts()
+		}
+
+		fmt.Fprint(w, mustJSON(map[string]interface{}{"config": map[string]interface{}{"dns": map[string]interface{}{"hosts": s.config.Hosts}}}))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/config/dns/cnameRecords":
+		fmt.Fprint(w, mustJSON(map[string]interface{}{"config": map[string]interface{}{"dns": map[string]interface{}{"cnameRecords": s.config.CNAMERecords}}}))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/config/misc/dnsmasq_lines":
+		fmt.Fprint(w, mustJSON(map[string]interface{}{"config": map[string]interface{}{"misc": map[string]interface{}{"dnsmasq_lines": s.config.DNSMasqLines}}}))
+	case r.Method == http.MethodPatch && r.URL.Path == "/api/config":
+		var body struct {
+			Config struct {
+				DNS struct {
+					Hosts        *[]string `json:"hosts"`
+					CNAMERecords *[]string `json:"cnameRecords"`
+				} `json:"dns"`
+				Misc struct {
+					DNSMasqLines *[]string `json:"dnsmasq_lines"`
+				} `json:"misc"`
+			} `json:"config"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if body.Config.DNS.Hosts != nil && s.rejectHost != "" && slices.Contains(*body.Config.DNS.Hosts, s.rejectHost) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, mustJSON(map[string]interface{}{"error": map[string]interface{}{"key": "bad_request", "message": "Invalid configuration", "hint": "dns.hosts: invalid entry " + s.rejectHost}}))
+			return
+		}
+
+		if body.Config.DNS.Hosts != nil {
+			s.config.Hosts = *body.Config.DNS.Hosts
+		}
+
+		if body.Config.DNS.CNAMERecords != nil {
+			s.config.CNAMERecords = *body.Config.DNS.CNAMERecords
+		}
+
+		if body.Config.Misc.DNSMasqLines != nil {
+			s.config.DNSMasqLines = *body.Config.Misc.DNSMasqLines
+		}
+
+		s.patches++
+		fmt.Fprint(w, "{}")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func mustJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(data)
+}
+
+func testBatchClient(t *testing.T, server *testDNSConfigServer, window time.Duration) *Client {
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	client := &Client{api: &apiClient{baseURL: ts.URL, http: ts.Client()}}
+	client.cache = &recordCache{api: client.api}
+	client.batcher = &dnsBatcher{api: client.api, window: window, writeMutex: &client.writeMutex, cache: client.cache}
+
+	return client
+}
+
+func TestDNSBatcherCoalescesWrites(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{Hosts: []string{"10.0.0.1 existing.com"}}}
+	client := testBatchClient(t, server, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			var err error
+			if i%2 == 0 {
+				err = client.createDNSRecord(context.Background(), pihole.DNSRecord{Domain: fmt.Sprintf("host%d.com", i), IP: "127.0.0.1"}, false)
+			} else {
+				err = client.createCNAMERecord(context.Background(), pihole.CNAMERecord{Domain: fmt.Sprintf("alias%d.com", i), Target: "existing.com"})
+			}
+
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	config, patches := server.state()
+	if patches != 1 {
+		t.Fatalf("expected a single configuration patch, got %d", patches)
+	}
+
+	if len(config.Hosts) != 6 || len(config.CNAMERecords) != 5 {
+		t.Fatalf("unexpected configuration after batch: %+v", config)
+	}
+}
+
+func TestDNSBatcherFailsConflictingOperationOnly(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{CNAMERecords: []string{"alias.com,target.com"}}}
+	client := testBatchClient(t, server, 0)
+
+	if err := client.createCNAMERecord(context.Background(), pihole.CNAMERecord{Domain: "alias.com", Target: "other.com"}); err == nil {
+		t.Fatal("expected conflicting CNAME record to fail")
+	}
+
+	if err := client.deleteCNAMERecord(context.Background(), "alias.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if config, _ := server.state(); len(config.CNAMERecords) != 0 {
+		t.Fatalf("expected CNAME record to be removed, got %v", config.CNAMERecords)
+	}
+}
+
+func TestDNSBatcherRetriesRejectedBatchIndividually(t *testing.T) {
+	server := &testDNSConfigServer{rejectHost: "10.0.0.300 bad.com"}
+	client := testBatchClient(t, server, 50*time.Millisecond)
+
+	errs := make(map[string]error)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, record := range []pihole.DNSRecord{{Domain: "good.com", IP: "10.0.0.1"}, {Domain: "bad.com", IP: "10.0.0.300"}, {Domain: "other.com", IP: "10.0.0.2"}} {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := client.createDNSRecord(context.Background(), record, false)
+
+			mu.Lock()
+			errs[record.Domain] = err
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if errs["good.com"] != nil || errs["other.com"] != nil {
+		t.Fatalf("expected valid records to be created, got %v", errs)
+	}
+
+	var apiErr *apiError
+	if !errors.As(errs["bad.com"], &apiErr) || apiErr.Key != apiErrorKeyBadRequest {
+		t.Fatalf("expected the invalid record to fail with the Pi-hole error, got %v", errs["bad.com"])
+	}
+
+	config, _ := server.state()
+	if !slices.Contains(config.Hosts, "10.0.0.1 good.com") || !slices.Contains(config.Hosts, "10.0.0.2 other.com") || len(config.Hosts) != 2 {
+		t.Fatalf("unexpected configuration after retry: %v", config.Hosts)
+	}
+}
+
+func TestDNSBatcherDropsCancelledOperations(t *testing.T) {
+	server := &testDNSConfigServer{}
+	client := testBatchClient(t, server, 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		if err := client.createDNSRecord(ctx, pihole.DNSRecord{Domain: "cancelled.com", IP: "10.0.0.1"}, false); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the cancelled operation to fail, got %v", err)
+		}
+	}()
+
+	// Submitted before the batch is flushed, so both end up in the same batch
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := client.createDNSRecord(context.Background(), pihole.DNSRecord{Domain: "kept.com", IP: "10.0.0.2"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+
+	if config, _ := server.state(); !slices.Equal(config.Hosts, []string{"10.0.0.2 kept.com"}) {
+		t.Fatalf("expected only the live operation to be written, got %v", config.Hosts)
+	}
+}
+
+func TestDNSBatcherMovesRecordsBetweenHostsAndHostRecords(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{
+		Hosts:        []string{"10.0.0.1 foo.com"},
+		DNSMasqLines: []string{"server=/example.com/10.0.0.53"},
+	}}
+	client := testBatchClient(t, server, 0)
+
+	if err := client.updateDNSRecord(context.Background(), pihole.DNSRecord{Domain: "foo.com", IP: "10.0.0.1", TTL: 30}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	config, _ := server.state()
+	if len(config.Hosts) != 0 {
+		t.Fatalf("expected record to be removed from dns.hosts, got %v", config.Hosts)
+	}
+
+	expected := []string{"server=/example.com/10.0.0.53", "host-record=foo.com,10.0.0.1,30"}
+	if !slices.Equal(config.DNSMasqLines, expected) {
+		t.Fatalf("expected %v, got %v", expected, config.DNSMasqLines)
+	}
+
+	record, err := client.getDNSRecord(context.Background(), "foo.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.TTL != 30 {
+		t.Fatalf("expected TTL 30, got %d", record.TTL)
+	}
+
+	// Opting out of host_record moves the record back to dns.hosts
+	if err := client.updateDNSRecord(context.Background(), pihole.DNSRecord{Domain: "foo.com", IP: "10.0.0.1"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	config, _ = server.state()
+	if !slices.Equal(config.Hosts, []string{"10.0.0.1 foo.com"}) || !slices.Equal(config.DNSMasqLines, []string{"server=/example.com/10.0.0.53"}) {
+		t.Fatalf("expected record to be moved back to dns.hosts, got %+v", config)
+	}
+}
+
+func TestDNSBatcherLeavesDNSMasqLinesAlone(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{DNSMasqLines: []string{"server=/example.com/10.0.0.53"}}}
+	client := testBatchClient(t, server, 0)
+
+	// A TTL without host_record is not stored, and misc.dnsmasq_lines is not rewritten
+	if err := client.createDNSRecord(context.Background(), pihole.DNSRecord{Domain: "foo.com", IP: "10.0.0.1", TTL: 30}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	config, _ := server.state()
+	if !slices.Equal(config.Hosts, []string{"10.0.0.1 foo.com"}) || !slices.Equal(config.DNSMasqLines, []string{"server=/example.com/10.0.0.53"}) {
+		t.Fatalf("unexpected configuration: %+v", config)
+	}
+}
+
+func TestDNSRecordCreateReleasesWriteLockWhilePolling(t *testing.T) {
+	server := &testDNSConfigServer{}
+	client := testBatchClient(t, server, 0)
+
+	// The last read of dns.hosts is the poll for the created record, onGetHosts runs with the server lock held
+	var locked bool
+	server.onGetHosts = func() {
+		locked = !client.writeMutex.TryLock()
+		if !locked {
+			client.writeMutex.Unlock()
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDNSRecord().Schema, map[string]interface{}{"domain": "foo.com", "ip": "10.0.0.1"})
+
+	if diags := resourceDNSRecordCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if locked {
+		t.Fatal("expected the write lock to be released while waiting for the record")
+	}
+}
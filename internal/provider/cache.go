@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+// recordCache serves local DNS and CNAME reads from a single fetch of the DNS configuration until the next write
+type recordCache struct {
+	api *apiClient
+
+	// disabled makes every read fetch the DNS configuration from Pi-hole
+	disabled bool
+
+	mu     sync.Mutex
+	config *dnsConfig
+
+	// generation is incremented on every invalidation so fetches started before a write are not cached
+	generation uint64
+}
+
+// get returns the cached DNS configuration, fetching it when the cache is empty
+func (c *recordCache) get(ctx context.Context) (*dnsConfig, error) {
+	c.mu.Lock()
+	config, generation := c.config, c.generation
+	c.mu.Unlock()
+
+	if config != nil {
+		return config, nil
+	}
+
+	config, err := c.api.getDNSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.disabled {
+		c.mu.Lock()
+		if c.generation == generation {
+			c.config = config
+		}
+		c.mu.Unlock()
+	}
+
+	return config, nil
+}
+
+// invalidate drops the cached DNS configuration
+func (c *recordCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.config = nil
+	c.generation++
+}
+
+// dnsRecords returns the local DNS records of the configuration, one per domain of each hosts entry
+// and one per domain and address of each host record
+func (c *dnsConfig) dnsRecords() []pihole.DNSRecord {
+	records := make([]pihole.DNSRecord, 0, len(c.Hosts))
+
+	for _, entry := range c.Hosts {
+		ip, domains, comment, ok := parseHostsEntry(entry)
+		if !ok {
+			continue
+		}
+
+		for _, domain := range domains {
+			records = append(records, pihole.DNSRecord{Domain: domain, IP: ip, Comment: comment})
+		}
+	}
+
+	for _, line := range c.DNSMasqLines {
+		record, ok := parseHostRecord(line)
+		if !ok {
+			continue
+		}
+
+		for _, domain := range record.Domains {
+			for _, ip := range record.IPs {
+				records = append(records, pihole.DNSRecord{Domain: domain, IP: ip, TTL: record.TTL, Comment: record.Comment})
+			}
+		}
+	}
+
+	return records
+}
+
+// hasHostRecord reports whether the local DNS record of a domain is stored as a host record in misc.dnsmasq_lines
+func (c *dnsConfig) hasHostRecord(domain string) bool {
+	for _, line := range c.DNSMasqLines {
+		record, ok := parseHostRecord(line)
+		if ok && slices.ContainsFunc(record.Domains, func(d string) bool { return sameDomain(d, domain) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cnameRecords returns the CNAME records of the configuration
+func (c *dnsConfig) cnameRecords() []pihole.CNAMERecord {
+	records := make([]pihole.CNAMERecord, 0, len(c.CNAMERecords))
+
+	for _, entry := range c.CNAMERecords {
+		if record, ok := parseCNAMEEntry(entry); ok {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// findDNSRecord returns the local DNS record of a domain
+func (c *dnsConfig) findDNSRecord(domain string) (*pihole.DNSRecord, error) {
+	for _, record := range c.dnsRecords() {
+		if sameDomain(record.Domain, domain) {
+			return &record, nil
+		}
+	}
+
+	return nil, pihole.ErrorLocalDNSNotFound
+}
+
+// findCNAMERecord returns the CNAME record of a domain
+func (c *dnsConfig) findCNAMERecord(domain string) (*pihole.CNAMERecord, error) {
+	for _, record := range c.cnameRecords() {
+		if sameDomain(record.Domain, domain) {
+			return &record, nil
+		}
+	}
+
+	return nil, pihole.ErrorLocalCNAMENotFound
+}
+
+// getDNSRecord returns the local DNS record of a domain, served from the read cache when enabled
+func (c *Client) getDNSRecord(ctx context.Context, domain string) (*pihole.DNSRecord, error) {
+	config, err := c.cache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.findDNSRecord(domain)
+}
+
+// getCNAMERecord returns the CNAME record of a domain, served from the read cache when enabled
+func (c *Client) getCNAMERecord(ctx context.Context, domain string) (*pihole.CNAMERecord, error) {
+	config, err := c.cache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.findCNAMERecord(domain)
+}
+
+// listDNSRecords returns all local DNS records, served from the read cache when enabled
+func (c *Client) listDNSRecords(ctx context.Context) ([]pihole.DNSRecord, error) {
+	config, err := c.cache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.dnsRecords(), nil
+}
+
+// listCNAMERecords returns all CNAME records, served from the read cache when enabled
+func (c *Client) listCNAMERecords(ctx context.Context) ([]pihole.CNAMERecord, error) {
+	config, err := c.cache.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.cnameRecords(), nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+func TestRecordCacheServesReadsUntilWrite(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{
+		Hosts:        []string{"10.0.0.1 foo.com bar.com"},
+		CNAMERecords: []string{"alias.com,foo.com,60"},
+	}}
+	client := testBatchClient(t, server, 0)
+
+	for _, domain := range []string{"foo.com", "bar.com"} {
+		record, err := client.getDNSRecord(context.Background(), domain)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if record.IP != "10.0.0.1" {
+			t.Fatalf("expected %s to resolve to 10.0.0.1, got %s", domain, record.IP)
+		}
+	}
+
+	record, err := client.getCNAMERecord(context.Background(), "alias.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Target != "foo.com" || record.TTL != 60 {
+		t.Fatalf("unexpected CNAME record %+v", record)
+	}
+
+	if server.gets != 1 {
+		t.Fatalf("expected reads to share a single fetch, got %d", server.gets)
+	}
+
+	if err := client.createDNSRecord(context.Background(), pihole.DNSRecord{Domain: "new.com", IP: "10.0.0.2"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.getDNSRecord(context.Background(), "new.com"); err != nil {
+		t.Fatalf("expected write to invalidate the cache: %s", err)
+	}
+
+	if _, err := client.getDNSRecord(context.Background(), "missing.com"); !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestRecordCacheDisabled(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{Hosts: []string{"10.0.0.1 foo.com"}}}
+	client := testBatchClient(t, server, 0)
+	client.cache.disabled = true
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.listDNSRecords(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if server.gets != 3 {
+		t.Fatalf("expected every read to fetch the records, got %d fetches", server.gets)
+	}
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// commentData is the data available to the default_comment template
+type commentData struct {
+	// Workspace is the selected Terraform workspace
+	Workspace string
+
+	// Resource is the resource type, e.g. pihole_dns_record
+	Resource string
+
+	Domain string
+	IP     string
+}
+
+// parseCommentTemplate parses a default_comment template
+func parseCommentTemplate(text string) (*template.Template, error) {
+	return template.New("default_comment").Option("missingkey=error").Parse(text)
+}
+
+// validateCommentTemplate validates that a string attribute is a valid default_comment template
+func validateCommentTemplate(value interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := parseCommentTemplate(value.(string)); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid comment template",
+				Detail:        err.Error(),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+// currentWorkspace returns the selected Terraform workspace, as set by TF_WORKSPACE or recorded in the Terraform data directory
+func currentWorkspace() string {
+	if workspace := os.Getenv("TF_WORKSPACE"); workspace != "" {
+		return workspace
+	}
+
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = ".terraform"
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dataDir, "environment")); err == nil {
+		if workspace := strings.TrimSpace(string(data)); workspace != "" {
+			return workspace
+		}
+	}
+
+	return "default"
+}
+
+// renderDefaultComment renders the provider default_comment for a record, empty when none is configured
+func (c *Client) renderDefaultComment(data commentData) (string, error) {
+	if c.defaultComment == nil {
+		return "", nil
+	}
+
+	data.Workspace = currentWorkspace()
+
+	var comment strings.Builder
+	if err := c.defaultComment.Execute(&comment, data); err != nil {
+		return "", fmt.Errorf("failed to render default_comment: %w", err)
+	}
+
+	return strings.Join(strings.Fields(comment.String()), " "), nil
+}
+
+// planComment plans the provider default_comment for records which do not configure a comment.
+// The attributes the template data is taken from must be known, otherwise the comment is only known after apply.
+func planComment(diff *schema.ResourceDiff, meta interface{}, data commentData, attributes ...string) error {
+	if !diff.GetRawConfig().GetAttr("comment").IsNull() {
+		return nil
+	}
+
+	// Without a default_comment, comments maintained outside of Terraform are left in place
+	client, ok := meta.(*Client)
+	if !ok || client.defaultComment == nil {
+		return nil
+	}
+
+	for _, attribute := range attributes {
+		if !diff.NewValueKnown(attribute) {
+			return diff.SetNewComputed("comment")
+		}
+	}
+
+	comment, err := client.renderDefaultComment(data)
+	if err != nil {
+		return err
+	}
+
+	if comment == diff.Get("comment").(string) {
+		return nil
+	}
+
+	return diff.SetNew("comment", comment)
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testDNSRecordCommentDiff plans a pihole_dns_record without a configured comment
+// whose state holds a comment maintained outside of Terraform
+func testDNSRecordCommentDiff(t *testing.T, client *Client) *terraform.InstanceDiff {
+	state := &terraform.InstanceState{
+		ID: "foo.com",
+		Attributes: map[string]string{
+			"id":      "foo.com",
+			"domain":  "foo.com",
+			"ip":      "10.0.0.1",
+			"ttl":     "0",
+			"comment": "set in the web interface",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"domain":  cty.StringVal("foo.com"),
+			"ip":      cty.StringVal("10.0.0.1"),
+			"ttl":     cty.NullVal(cty.Number),
+			"comment": cty.NullVal(cty.String),
+		}),
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"domain": "foo.com",
+		"ip":     "10.0.0.1",
+	})
+
+	diff, err := resourceDNSRecord().SimpleDiff(context.Background(), state, config, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return diff
+}
+
+func TestPlanCommentWithoutTemplateKeepsComment(t *testing.T) {
+	if diff := testDNSRecordCommentDiff(t, &Client{}); diff != nil && diff.Attributes["comment"] != nil {
+		t.Fatalf("expected the existing comment to be kept, got %+v", diff.Attributes["comment"])
+	}
+}
+
+func TestPlanCommentRendersTemplate(t *testing.T) {
+	tmpl, err := parseCommentTemplate("{{ .Resource }} {{ .Domain }}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := testDNSRecordCommentDiff(t, &Client{defaultComment: tmpl})
+	if diff == nil || diff.Attributes["comment"] == nil || diff.Attributes["comment"].New != "pihole_dns_record foo.com" {
+		t.Fatalf("expected the default comment to be planned, got %+v", diff)
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
+	"sync"
+	"text/template"
+	"time"
 
 	pihole "github.com/awaybreaktoday/lib-pihole-go"
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
@@ -23,6 +27,12 @@ type Config struct {
 	// API token used for token-based authentication
 	APIToken string
 
+	// Pi-hole application password, used in place of the admin password
+	AppPassword string
+
+	// Base32 encoded secret used to generate TOTP codes when two-factor authentication is enabled
+	TOTPSecret string
+
 	// UserAgent for requests
 	UserAgent string
 
@@ -31,9 +41,44 @@ type Config struct {
 
 	// SessionID can be passed to reduce the number of requests against the /api/auth endpoint
 	SessionID string
+
+	// SessionCacheDir enables reusing sessions across provider runs when set
+	SessionCacheDir string
+
+	// Maximum number of concurrent requests sent to Pi-hole, unlimited when zero
+	MaxConcurrentRequests int
+
+	// Maximum number of requests per second sent to Pi-hole, unlimited when zero
+	RequestsPerSecond float64
+
+	// BatchWindow is how long local DNS and CNAME changes are collected before being written together
+	BatchWindow time.Duration
+
+	// DisableReadCache makes every local DNS and CNAME read fetch the records from Pi-hole
+	DisableReadCache bool
+
+	// DefaultComment is a text/template rendered as the comment of records which do not configure one
+	DefaultComment string
 }
 
-func (c Config) Client(ctx context.Context) (*pihole.Client, error) {
+// Client wraps the lib-pihole-go client with the state shared by all resources of a provider instance
+type Client struct {
+	*pihole.Client
+
+	api *apiClient
+
+	// writeMutex serializes Pi-hole configuration changes across all resource types.
+	// It is held only while a change is read, applied and written, never while waiting for the change to become visible.
+	writeMutex sync.Mutex
+
+	batcher *dnsBatcher
+	cache   *recordCache
+
+	// defaultComment is the parsed DefaultComment template, nil when unset
+	defaultComment *template.Template
+}
+
+func (c Config) Client(ctx context.Context) (*Client, error) {
 	retryClient := retryablehttp.NewClient()
 
 	if c.CAFile != "" {
@@ -72,19 +117,139 @@ func (c Config) Client(ctx context.Context) (*pihole.Client, error) {
 		retryClient.HTTPClient.Transport = clonedTransport
 	}
 
+	baseTransport := retryClient.HTTPClient.Transport
+	if baseTransport == nil {
+		baseTransport = http.DefaultTransport
+	}
+
+	// Requests are logged via tflog instead of the retryablehttp logger, including the retry attempt
+	retryClient.Logger = nil
+	retryClient.RequestLogHook = logRetryAttempt
+	retryClient.HTTPClient.Transport = &loggingTransport{base: baseTransport, logBodies: apiLogBodiesEnabled()}
+
+	if c.MaxConcurrentRequests > 0 || c.RequestsPerSecond > 0 {
+		retryClient.HTTPClient.Transport = &limitedTransport{
+			base:    retryClient.HTTPClient.Transport,
+			limiter: newRequestLimiter(c.MaxConcurrentRequests, c.RequestsPerSecond),
+		}
+	}
+
 	httpClient := retryClient.StandardClient()
 
 	headers := http.Header{}
 	headers.Add("User-Agent", c.UserAgent)
 
+	api := &apiClient{
+		baseURL: c.URL,
+		http:    httpClient,
+		headers: headers,
+	}
+
+	sessionID, err := c.session(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
 	config := pihole.Config{
 		BaseURL:    c.URL,
-		Password:   c.Password,
+		Password:   c.password(),
 		APIToken:   c.APIToken,
 		Headers:    headers,
 		HttpClient: httpClient,
-		SessionID:  c.SessionID,
+		SessionID:  sessionID,
 	}
 
-	return pihole.New(config)
+	client, err := pihole.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pi-hole v6 accepts a pre-issued token in place of a session ID
+	if sessionID == "" {
+		sessionID = c.APIToken
+	}
+
+	result := &Client{
+		Client: client,
+		api:    api.withSession(sessionID),
+	}
+
+	if c.DefaultComment != "" {
+		result.defaultComment, err = parseCommentTemplate(c.DefaultComment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default_comment: %w", err)
+		}
+	}
+
+	result.cache = &recordCache{
+		api:      result.api,
+		disabled: c.DisableReadCache,
+	}
+
+	result.batcher = &dnsBatcher{
+		api:        result.api,
+		window:     c.BatchWindow,
+		writeMutex: &result.writeMutex,
+		cache:      result.cache,
+	}
+
+	return result, nil
+}
+
+// password returns the credential used to login, either the admin password or an application password
+func (c Config) password() string {
+	if c.AppPassword != "" {
+		return c.AppPassword
+	}
+
+	return c.Password
+}
+
+// session returns the session ID to authenticate with, reusing a cached session when possible.
+// The session ID is registered as used by the provider so it is never revoked by a session cleanup.
+func (c Config) session(ctx context.Context, api *apiClient) (string, error) {
+	sessionID, err := c.openSession(ctx, api)
+	if err == nil && sessionID != "" {
+		useSession(api.baseURL, sessionID)
+	}
+
+	return sessionID, err
+}
+
+// openSession returns the configured or cached session ID, or logs in to open a new session
+func (c Config) openSession(ctx context.Context, api *apiClient) (string, error) {
+	password := c.password()
+	if c.SessionID != "" || password == "" {
+		return c.SessionID, nil
+	}
+
+	var cache *sessionCache
+	if c.SessionCacheDir != "" {
+		cache = &sessionCache{dir: c.SessionCacheDir}
+
+		if sessionID, ok := cache.load(c.URL, password); ok {
+			valid, err := api.validateSession(ctx, sessionID)
+			if err == nil && valid {
+				return sessionID, nil
+			}
+		}
+	}
+
+	s, err := api.login(ctx, password, c.TOTPSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to login to Pi-hole: %w", err)
+	}
+
+	if cache != nil {
+		err := cache.store(c.URL, password, s)
+		if err == nil {
+			return s.SID, nil
+		}
+
+		log.Printf("[WARN] Unable to cache Pi-hole session, it will be closed at the end of the run: %s", err)
+	}
+
+	trackSession(api, s.SID)
+
+	return s.SID, nil
 }
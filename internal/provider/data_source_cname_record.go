@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceCNAMERecord returns a schema resource for looking up a single Pi-hole CNAME record
+func dataSourceCNAMERecord() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up the Pi-hole CNAME record of a domain",
+		ReadContext: dataSourceCNAMERecordRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description:      "CNAME record domain to look up",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDomain,
+			},
+			"allow_missing": {
+				Description: "Return empty attributes with `found` set to `false` instead of failing when the domain has no CNAME record. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"found": {
+				Description: "Whether a CNAME record exists for the domain",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"target": {
+				Description: "CNAME target value where traffic is routed to from the domain",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ttl": {
+				Description: "TTL (in seconds) of the CNAME record, `0` when it uses the Pi-hole default TTL.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceCNAMERecordRead looks up the CNAME record of a domain
+func dataSourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	record, err := client.getCNAMERecord(ctx, domain)
+	found := err == nil
+
+	if !found {
+		if !errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
+			return diagFromErr(err, d)
+		}
+
+		if !d.Get("allow_missing").(bool) {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "CNAME record not found",
+					Detail:        fmt.Sprintf("Pi-hole has no CNAME record for %q. Set allow_missing to tolerate missing records.", domain),
+					AttributePath: cty.GetAttrPath("domain"),
+				},
+			}
+		}
+
+		record = &pihole.CNAMERecord{}
+	}
+
+	if err = d.Set("found", found); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("target", record.Target); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("ttl", record.TTL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(domain)
+
+	return diags
+}
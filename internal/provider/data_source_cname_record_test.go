@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCNAMERecordData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_cname_record" "record" {
+					  domain = "foo.com"
+					  target = "bar.com"
+					}
+
+					data "pihole_cname_record" "record" {
+					  domain     = "foo.com"
+					  depends_on = [pihole_cname_record.record]
+					}
+
+					data "pihole_cname_record" "missing" {
+					  domain        = "missing.com"
+					  allow_missing = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_cname_record.record", "found", "true"),
+					resource.TestCheckResourceAttr("data.pihole_cname_record.record", "target", "bar.com"),
+					resource.TestCheckResourceAttr("data.pihole_cname_record.missing", "found", "false"),
+				),
+			},
+			{
+				Config: `
+					data "pihole_cname_record" "missing" {
+					  domain = "missing.com"
+					}
+				`,
+				ExpectError: regexp.MustCompile("CNAME record not found"),
+			},
+		},
+	})
+}
@@ -16,7 +16,15 @@ import (
 func dataSourceCNAMERecords() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceCNAMERecordsRead,
-		Schema: map[string]*schema.Schema{
+		Schema: withRecordFilters(map[string]*schema.Schema{
+			"by_domain": {
+				Description: "Map of CNAME record domains to their target, each domain has a single CNAME record.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"by_target": recordIndexSchema("CNAME targets with the sorted record domains pointing at them, e.g. `{ for entry in by_target : entry.target => entry.domains }`.",
+				"target", "CNAME target", "domains", "Domains of the CNAME records pointing at the target"),
 			"records": {
 				Description: "List of CNAME Pi-hole records",
 				Type:        schema.TypeSet,
@@ -41,22 +49,34 @@ func dataSourceCNAMERecords() *schema.Resource {
 					},
 				},
 			},
-		},
+		}, "domain_regex", "domain_suffix", "target"),
 	}
 }
 
 // dataSourceCNAMERecordsRead lists all Pi-hole CNAME records
 func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	cnameList, err := client.LocalCNAME.List(ctx)
+	filter, err := newRecordFilter(d, "domain_regex", "domain_suffix", "target")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	records, err := client.listCNAMERecords(ctx)
+	if err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	cnameList := make([]pihole.CNAMERecord, 0, len(records))
+	for _, record := range records {
+		if filter.matchCNAMERecord(record) {
+			cnameList = append(cnameList, record)
+		}
+	}
+
 	sort.Slice(cnameList, func(i, j int) bool {
 		if cnameList[i].Domain == cnameList[j].Domain {
 			return cnameList[i].Target < cnameList[j].Target
@@ -66,6 +86,7 @@ func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, met
 	})
 
 	list := make([]map[string]interface{}, len(cnameList))
+	byDomain, byTarget := make(map[string]interface{}, len(cnameList)), recordIndex{}
 	hash := sha256.New()
 
 	for i, r := range cnameList {
@@ -81,12 +102,23 @@ func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, met
 			"target": r.Target,
 			"ttl":    r.TTL,
 		}
+
+		byDomain[r.Domain] = r.Target
+		byTarget.add(r.Target, r.Domain)
 	}
 
 	if err := d.Set("records", list); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("by_domain", byDomain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("by_target", byTarget.flatten("target", "domains")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
 
 	return diags
@@ -27,6 +27,47 @@ func TestAccCNAMERecordsData(t *testing.T) {
 
 					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.domain", "foo.com"),
 					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.target", "bar.com"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "by_domain.foo.com", "bar.com"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "by_target.0.target", "bar.com"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "by_target.0.domains.0", "foo.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCNAMERecordsDataFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_cname_record" "web" {
+					  domain = "web.lab.example.com"
+					  target = "ingress.example.com"
+					}
+
+					resource "pihole_cname_record" "db" {
+					  domain = "db.example.com"
+					  target = "postgres.example.com"
+					}
+
+					data "pihole_cname_records" "ingress" {
+					  target     = "ingress.example.com"
+					  depends_on = [pihole_cname_record.web, pihole_cname_record.db]
+					}
+
+					data "pihole_cname_records" "lab" {
+					  domain_suffix = "lab.example.com"
+					  depends_on    = [pihole_cname_record.web, pihole_cname_record.db]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_cname_records.ingress", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.ingress", "records.0.domain", "web.lab.example.com"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.lab", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.lab", "records.0.target", "ingress.example.com"),
 				),
 			},
 		},
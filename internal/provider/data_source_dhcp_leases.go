@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dhcpLease is an active lease of the Pi-hole DHCP server
+type dhcpLease struct {
+	Expires  int64  `json:"expires"`
+	Name     string `json:"name"`
+	HWAddr   string `json:"hwaddr"`
+	IP       string `json:"ip"`
+	ClientID string `json:"clientid"`
+}
+
+// dhcpLeasesResponse is the response of /api/dhcp/leases
+type dhcpLeasesResponse struct {
+	Leases []dhcpLease `json:"leases"`
+}
+
+// dataSourceDHCPLeases returns a schema resource for listing the active leases of the Pi-hole DHCP server
+func dataSourceDHCPLeases() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the active leases of the Pi-hole DHCP server",
+		ReadContext: dataSourceDHCPLeasesRead,
+		Schema: map[string]*schema.Schema{
+			"hostname_regex": {
+				Description:      "Only return leases whose hostname matches this regular expression.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"ip_cidr": {
+				Description:      "Only return leases whose IP address is within this CIDR block.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsCIDR),
+			},
+			"leases": {
+				Description: "Active DHCP leases, ordered by IP address",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Description: "Leased IP address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"mac": {
+							Description: "Hardware address of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"hostname": {
+							Description: "Hostname sent by the client, empty when unknown",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client_id": {
+							Description: "DHCP client identifier, empty when not sent",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"expires": {
+							Description: "RFC 3339 expiry time of the lease, empty for infinite leases",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceDHCPLeasesRead lists the active DHCP leases matching the filters
+func dataSourceDHCPLeasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var hostnameRegex *regexp.Regexp
+	if value := d.Get("hostname_regex").(string); value != "" {
+		var err error
+		if hostnameRegex, err = regexp.Compile(value); err != nil {
+			return diag.Errorf("invalid hostname_regex: %s", err)
+		}
+	}
+
+	var ipCIDR netip.Prefix
+	if value := d.Get("ip_cidr").(string); value != "" {
+		var err error
+		if ipCIDR, err = netip.ParsePrefix(value); err != nil {
+			return diag.Errorf("invalid ip_cidr: %s", err)
+		}
+	}
+
+	var res dhcpLeasesResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/dhcp/leases", nil, &res); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	leases := make([]dhcpLease, 0, len(res.Leases))
+	for _, lease := range res.Leases {
+		if matchDHCPLease(lease, hostnameRegex, ipCIDR) {
+			leases = append(leases, lease)
+		}
+	}
+
+	sort.Slice(leases, func(i, j int) bool {
+		a, errA := netip.ParseAddr(leases[i].IP)
+		b, errB := netip.ParseAddr(leases[j].IP)
+		if errA != nil || errB != nil {
+			return leases[i].IP < leases[j].IP
+		}
+
+		return a.Less(b)
+	})
+
+	list := make([]map[string]interface{}, len(leases))
+	hash := sha256.New()
+
+	for i, lease := range leases {
+		hash.Write([]byte(lease.IP))
+		hash.Write([]byte{0})
+		hash.Write([]byte(lease.HWAddr))
+		hash.Write([]byte{0})
+
+		list[i] = map[string]interface{}{
+			"ip":        lease.IP,
+			"mac":       lease.HWAddr,
+			"hostname":  dhcpLeaseValue(lease.Name),
+			"client_id": dhcpLeaseValue(lease.ClientID),
+			"expires":   formatUnixTime(lease.Expires),
+		}
+	}
+
+	if err := d.Set("leases", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
+
+// matchDHCPLease reports whether a lease matches the hostname_regex and ip_cidr filters, unset filters match every lease
+func matchDHCPLease(lease dhcpLease, hostnameRegex *regexp.Regexp, ipCIDR netip.Prefix) bool {
+	if hostnameRegex != nil && !hostnameRegex.MatchString(dhcpLeaseValue(lease.Name)) {
+		return false
+	}
+
+	if ipCIDR.IsValid() {
+		ip, err := netip.ParseAddr(lease.IP)
+		if err != nil || !ipCIDR.Contains(ip.Unmap()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dhcpLeaseValue returns a lease field, empty when dnsmasq reports it as unknown with "*"
+func dhcpLeaseValue(value string) string {
+	if value == "*" {
+		return ""
+	}
+
+	return value
+}
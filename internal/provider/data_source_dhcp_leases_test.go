@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"net/netip"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDHCPLeasesData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_dhcp_leases" "all" {}
+
+					data "pihole_dhcp_leases" "filtered" {
+					  hostname_regex = "^printer"
+					  ip_cidr        = "192.0.2.0/24"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_dhcp_leases.all", "leases.#"),
+					resource.TestCheckResourceAttr("data.pihole_dhcp_leases.filtered", "leases.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchDHCPLease(t *testing.T) {
+	lease := dhcpLease{IP: "192.168.1.20", Name: "printer-office", HWAddr: "aa:bb:cc:dd:ee:ff"}
+	unnamed := dhcpLease{IP: "192.168.1.21", Name: "*"}
+
+	cases := []struct {
+		name  string
+		lease dhcpLease
+		regex *regexp.Regexp
+		cidr  netip.Prefix
+		want  bool
+	}{
+		{"no filters", lease, nil, netip.Prefix{}, true},
+		{"hostname match", lease, regexp.MustCompile("^printer"), netip.Prefix{}, true},
+		{"hostname mismatch", lease, regexp.MustCompile("^laptop"), netip.Prefix{}, false},
+		{"unknown hostname", unnamed, regexp.MustCompile(`^\*$`), netip.Prefix{}, false},
+		{"subnet match", lease, nil, netip.MustParsePrefix("192.168.1.0/24"), true},
+		{"subnet mismatch", lease, nil, netip.MustParsePrefix("10.0.0.0/8"), false},
+	}
+
+	for _, c := range cases {
+		if got := matchDHCPLease(c.lease, c.regex, c.cidr); got != c.want {
+			t.Errorf("%s: matchDHCPLease() = %t, want %t", c.name, got, c.want)
+		}
+	}
+}
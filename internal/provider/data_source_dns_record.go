@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceDNSRecord returns a schema resource for looking up a single Pi-hole local DNS record
+func dataSourceDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up the Pi-hole local DNS record of a domain",
+		ReadContext: dataSourceDNSRecordRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description:      "DNS record domain to look up",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDomain,
+			},
+			"allow_missing": {
+				Description: "Return empty attributes with `found` set to `false` instead of failing when the domain has no local DNS record. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"found": {
+				Description: "Whether a local DNS record exists for the domain",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"ip": {
+				Description: "IP address where traffic is routed to from the DNS record domain",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ttl": {
+				Description: "TTL (in seconds) of the DNS record, `0` when it uses the Pi-hole default TTL.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "Comment associated with the DNS record, if present.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceDNSRecordRead looks up the local DNS record of a domain
+func dataSourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	record, err := client.getDNSRecord(ctx, domain)
+	found := err == nil
+
+	if !found {
+		if !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
+			return diagFromErr(err, d)
+		}
+
+		if !d.Get("allow_missing").(bool) {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Local DNS record not found",
+					Detail:        fmt.Sprintf("Pi-hole has no local DNS record for %q. Set allow_missing to tolerate missing records.", domain),
+					AttributePath: cty.GetAttrPath("domain"),
+				},
+			}
+		}
+
+		record = &pihole.DNSRecord{}
+	}
+
+	if err = d.Set("found", found); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("ip", record.IP); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("ttl", record.TTL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", record.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(domain)
+
+	return diags
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDNSRecordData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_dns_record" "record" {
+					  domain = "foo.com"
+					  ip     = "127.0.0.1"
+					}
+
+					data "pihole_dns_record" "record" {
+					  domain     = "FOO.com."
+					  depends_on = [pihole_dns_record.record]
+					}
+
+					data "pihole_dns_record" "missing" {
+					  domain        = "missing.com"
+					  allow_missing = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_record.record", "found", "true"),
+					resource.TestCheckResourceAttr("data.pihole_dns_record.record", "ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_record.missing", "found", "false"),
+					resource.TestCheckResourceAttr("data.pihole_dns_record.missing", "ip", ""),
+				),
+			},
+			{
+				Config: `
+					data "pihole_dns_record" "missing" {
+					  domain = "missing.com"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Local DNS record not found"),
+			},
+		},
+	})
+}
@@ -16,7 +16,11 @@ import (
 func dataSourceDNSRecords() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDNSRecordsRead,
-		Schema: map[string]*schema.Schema{
+		Schema: withRecordFilters(map[string]*schema.Schema{
+			"by_domain": recordIndexSchema("Record domains with their sorted IP addresses, e.g. `{ for entry in by_domain : entry.domain => entry.ips }`.",
+				"domain", "DNS record domain", "ips", "IP addresses of the domain's records"),
+			"by_ip": recordIndexSchema("IP addresses with the sorted record domains resolving to them, e.g. `{ for entry in by_ip : entry.ip => entry.domains }`.",
+				"ip", "IP address", "domains", "Domains of the records resolving to the IP address"),
 			"records": {
 				Description: "List of Pi-hole DNS records",
 				Type:        schema.TypeSet,
@@ -34,7 +38,7 @@ func dataSourceDNSRecords() *schema.Resource {
 							Computed:    true,
 						},
 						"ttl": {
-							Description: "TTL (in seconds) returned by Pi-hole for the DNS record.",
+							Description: "TTL (in seconds) of the DNS record, `0` when it uses the Pi-hole default TTL.",
 							Type:        schema.TypeInt,
 							Computed:    true,
 						},
@@ -46,22 +50,34 @@ func dataSourceDNSRecords() *schema.Resource {
 					},
 				},
 			},
-		},
+		}, "domain_regex", "domain_suffix", "ip_cidr", "comment_contains"),
 	}
 }
 
 // dataSourceDNSRecordsRead lists all Pi-hole local DNS records
 func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	dnsList, err := client.LocalDNS.List(ctx)
+	filter, err := newRecordFilter(d, "domain_regex", "domain_suffix", "ip_cidr", "comment_contains")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	records, err := client.listDNSRecords(ctx)
+	if err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	dnsList := make([]pihole.DNSRecord, 0, len(records))
+	for _, record := range records {
+		if filter.matchDNSRecord(record) {
+			dnsList = append(dnsList, record)
+		}
+	}
+
 	sort.Slice(dnsList, func(i, j int) bool {
 		if dnsList[i].Domain == dnsList[j].Domain {
 			return dnsList[i].IP < dnsList[j].IP
@@ -71,6 +87,7 @@ func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta
 	})
 
 	list := make([]map[string]interface{}, len(dnsList))
+	byDomain, byIP := recordIndex{}, recordIndex{}
 	hash := sha256.New()
 
 	for i, r := range dnsList {
@@ -89,12 +106,23 @@ func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta
 			"ttl":     r.TTL,
 			"comment": r.Comment,
 		}
+
+		byDomain.add(r.Domain, r.IP)
+		byIP.add(r.IP, r.Domain)
 	}
 
 	if err := d.Set("records", list); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("by_domain", byDomain.flatten("domain", "ips")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("by_ip", byIP.flatten("ip", "domains")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
 
 	return diags
@@ -26,6 +26,57 @@ func TestAccDNSRecordsData(t *testing.T) {
 
 					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "records.0.domain", "foo.com"),
 					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "records.0.ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "by_domain.0.domain", "foo.com"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "by_domain.0.ips.0", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "by_ip.0.ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "by_ip.0.domains.0", "foo.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordsDataFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_dns_record" "web" {
+					  domain  = "web.lab.example.com"
+					  ip      = "10.0.0.10"
+					  comment = "ticket NET-123"
+					}
+
+					resource "pihole_dns_record" "db" {
+					  domain = "db.example.com"
+					  ip     = "192.168.1.10"
+					}
+
+					data "pihole_dns_records" "lab" {
+					  domain_suffix = "lab.example.com"
+					  depends_on    = [pihole_dns_record.web, pihole_dns_record.db]
+					}
+
+					data "pihole_dns_records" "private" {
+					  ip_cidr    = "192.168.0.0/16"
+					  depends_on = [pihole_dns_record.web, pihole_dns_record.db]
+					}
+
+					data "pihole_dns_records" "ticket" {
+					  domain_regex     = "^web\\."
+					  comment_contains = "NET-123"
+					  depends_on       = [pihole_dns_record.web, pihole_dns_record.db]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_records.lab", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.lab", "records.0.domain", "web.lab.example.com"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.private", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.private", "records.0.domain", "db.example.com"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.ticket", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.ticket", "records.0.ip", "10.0.0.10"),
 				),
 			},
 		},
@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// domainSearchResponse is the response of /api/search/{domain}
+type domainSearchResponse struct {
+	Search struct {
+		Domains []struct {
+			ID      int64   `json:"id"`
+			Domain  string  `json:"domain"`
+			Type    string  `json:"type"`
+			Kind    string  `json:"kind"`
+			Enabled bool    `json:"enabled"`
+			Comment *string `json:"comment"`
+			Groups  []int64 `json:"groups"`
+		} `json:"domains"`
+		Gravity []struct {
+			ID      int64   `json:"id"`
+			Domain  string  `json:"domain"`
+			Address string  `json:"address"`
+			Type    string  `json:"type"`
+			Enabled bool    `json:"enabled"`
+			Comment *string `json:"comment"`
+			Groups  []int64 `json:"groups"`
+		} `json:"gravity"`
+	} `json:"search"`
+}
+
+// groupsResponse is the response of /api/groups
+type groupsResponse struct {
+	Groups []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"groups"`
+}
+
+// domainSearchGroupSchemas are the group attributes of domain search matches
+var domainSearchGroupSchemas = map[string]*schema.Schema{
+	"groups": {
+		Description: "IDs of the groups the entry is assigned to",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+	},
+	"group_names": {
+		Description: "Names of the groups the entry is assigned to",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+// dataSourceDomainSearch returns a schema resource for finding the list entries and adlists matching a domain
+func dataSourceDomainSearch() *schema.Resource {
+	return &schema.Resource{
+		Description: "Searches the allow and deny lists and the adlists for a domain, explaining why it is blocked or allowed",
+		ReadContext: dataSourceDomainSearchRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description:      "Domain to search for",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDomain,
+			},
+			"partial": {
+				Description: "Also return entries containing the domain as a substring. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"max_results": {
+				Description:      "Maximum number of matches to return per list type. Defaults to `20`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          20,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"domains": {
+				Description: "Matching exact and regex allow and deny list entries",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: withDomainSearchGroups(map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the list entry",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Domain or regular expression of the list entry",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "`allow` or `deny`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"kind": {
+							Description: "`exact` or `regex`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enabled": {
+							Description: "Whether the list entry is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"comment": {
+							Description: "Comment of the list entry",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					}),
+				},
+			},
+			"adlists": {
+				Description: "Adlists containing the domain",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: withDomainSearchGroups(map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the adlist",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Matching domain on the adlist",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"address": {
+							Description: "URL of the adlist",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "`block` for blocklists or `allow` for allowlists",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enabled": {
+							Description: "Whether the adlist is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"comment": {
+							Description: "Comment of the adlist",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// withDomainSearchGroups adds the group attributes to a domain search match schema
+func withDomainSearchGroups(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for attribute, groupSchema := range domainSearchGroupSchemas {
+		groupSchema := *groupSchema
+		s[attribute] = &groupSchema
+	}
+
+	return s
+}
+
+// dataSourceDomainSearchRead searches the lists for a domain and resolves the group names of the matches
+func dataSourceDomainSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	query := url.Values{}
+	query.Set("partial", strconv.FormatBool(d.Get("partial").(bool)))
+	query.Set("N", strconv.Itoa(d.Get("max_results").(int)))
+
+	var search domainSearchResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/search/"+url.PathEscape(domain)+"?"+query.Encode(), nil, &search); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	var groups groupsResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/groups", nil, &groups); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	groupNames := make(map[int64]string, len(groups.Groups))
+	for _, group := range groups.Groups {
+		groupNames[group.ID] = group.Name
+	}
+
+	domains := make([]map[string]interface{}, len(search.Search.Domains))
+	for i, entry := range search.Search.Domains {
+		domains[i] = map[string]interface{}{
+			"id":          entry.ID,
+			"domain":      entry.Domain,
+			"type":        entry.Type,
+			"kind":        entry.Kind,
+			"enabled":     entry.Enabled,
+			"comment":     stringValue(entry.Comment),
+			"groups":      entry.Groups,
+			"group_names": resolveGroupNames(entry.Groups, groupNames),
+		}
+	}
+
+	adlists := make([]map[string]interface{}, len(search.Search.Gravity))
+	for i, entry := range search.Search.Gravity {
+		adlists[i] = map[string]interface{}{
+			"id":          entry.ID,
+			"domain":      entry.Domain,
+			"address":     entry.Address,
+			"type":        entry.Type,
+			"enabled":     entry.Enabled,
+			"comment":     stringValue(entry.Comment),
+			"groups":      entry.Groups,
+			"group_names": resolveGroupNames(entry.Groups, groupNames),
+		}
+	}
+
+	if err := d.Set("domains", domains); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("adlists", adlists); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(domain)
+
+	return diags
+}
+
+// resolveGroupNames returns the names of group IDs, falling back to the ID of unknown groups
+func resolveGroupNames(ids []int64, names map[int64]string) []string {
+	resolved := make([]string, len(ids))
+	for i, id := range ids {
+		name, ok := names[id]
+		if !ok {
+			name = strconv.FormatInt(id, 10)
+		}
+
+		resolved[i] = name
+	}
+
+	return resolved
+}
+
+// stringValue returns the value of an optional string, empty when nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDomainSearchData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_domain_search" "search" {
+					  domain      = "Ads.Example.com."
+					  max_results = 5
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_domain_search.search", "id", "ads.example.com"),
+					resource.TestCheckResourceAttrSet("data.pihole_domain_search.search", "domains.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_domain_search.search", "adlists.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestResolveGroupNames(t *testing.T) {
+	names := map[int64]string{0: "Default", 2: "kids"}
+
+	got := resolveGroupNames([]int64{2, 0, 5}, names)
+	if want := []string{"kids", "Default", "5"}; !slices.Equal(got, want) {
+		t.Errorf("resolveGroupNames() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// recordFilterSchemas are the optional filter arguments of the record list data sources
+var recordFilterSchemas = map[string]*schema.Schema{
+	"domain_regex": {
+		Description:      "Only return records whose domain matches this regular expression.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+	},
+	"domain_suffix": {
+		Description:      "Only return records for this domain and its subdomains.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateDomain,
+	},
+	"ip_cidr": {
+		Description:      "Only return records whose IP address is within this CIDR block.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IsCIDR),
+	},
+	"target": {
+		Description:      "Only return records pointing at this target.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateDomain,
+	},
+	"comment_contains": {
+		Description: "Only return records whose comment contains this string.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	},
+}
+
+// withRecordFilters adds the named filter arguments to a data source schema
+func withRecordFilters(s map[string]*schema.Schema, attributes ...string) map[string]*schema.Schema {
+	for _, attribute := range attributes {
+		filter := *recordFilterSchemas[attribute]
+		s[attribute] = &filter
+	}
+
+	return s
+}
+
+// recordFilter matches records against the filter arguments of a data source, unset filters match every record
+type recordFilter struct {
+	domainRegex     *regexp.Regexp
+	domainSuffix    string
+	ipCIDR          netip.Prefix
+	target          string
+	commentContains string
+}
+
+// newRecordFilter returns the filter configured by the named filter arguments of a data source
+func newRecordFilter(d *schema.ResourceData, attributes ...string) (*recordFilter, error) {
+	filter := &recordFilter{}
+
+	for _, attribute := range attributes {
+		value := d.Get(attribute).(string)
+		if value == "" {
+			continue
+		}
+
+		var err error
+
+		switch attribute {
+		case "domain_regex":
+			filter.domainRegex, err = regexp.Compile(value)
+		case "domain_suffix":
+			filter.domainSuffix = normalizeDomain(strings.TrimPrefix(value, "."))
+		case "ip_cidr":
+			filter.ipCIDR, err = netip.ParsePrefix(value)
+		case "target":
+			filter.target = normalizeDomain(value)
+		case "comment_contains":
+			filter.commentContains = value
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", attribute, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// matchDomain reports whether a domain matches the domain_regex and domain_suffix filters
+func (f *recordFilter) matchDomain(domain string) bool {
+	if f.domainRegex != nil && !f.domainRegex.MatchString(domain) {
+		return false
+	}
+
+	if f.domainSuffix != "" {
+		name := normalizeDomain(domain)
+		if name != f.domainSuffix && !strings.HasSuffix(name, "."+f.domainSuffix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchComment reports whether a comment matches the comment_contains filter
+func (f *recordFilter) matchComment(comment string) bool {
+	return f.commentContains == "" || strings.Contains(comment, f.commentContains)
+}
+
+// matchDNSRecord reports whether a local DNS record matches the filter
+func (f *recordFilter) matchDNSRecord(record pihole.DNSRecord) bool {
+	if !f.matchDomain(record.Domain) || !f.matchComment(record.Comment) {
+		return false
+	}
+
+	if f.ipCIDR.IsValid() {
+		ip, err := netip.ParseAddr(record.IP)
+		if err != nil || !f.ipCIDR.Contains(ip.Unmap()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchCNAMERecord reports whether a CNAME record matches the filter
+func (f *recordFilter) matchCNAMERecord(record pihole.CNAMERecord) bool {
+	if !f.matchDomain(record.Domain) {
+		return false
+	}
+
+	return f.target == "" || sameDomain(record.Target, f.target)
+}
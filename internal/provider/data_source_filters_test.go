@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"net/netip"
+	"regexp"
+	"testing"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+func TestRecordFilterMatchDNSRecord(t *testing.T) {
+	filter := &recordFilter{
+		domainSuffix:    "lab.example.com",
+		ipCIDR:          netip.MustParsePrefix("10.0.0.0/8"),
+		commentContains: "NET-",
+	}
+
+	matching := pihole.DNSRecord{Domain: "web.lab.example.com", IP: "10.1.2.3", Comment: "ticket NET-123"}
+	if !filter.matchDNSRecord(matching) {
+		t.Errorf("expected %+v to match", matching)
+	}
+
+	for _, record := range []pihole.DNSRecord{
+		{Domain: "weblab.example.com", IP: "10.1.2.3", Comment: "ticket NET-123"},
+		{Domain: "web.lab.example.com", IP: "192.168.1.1", Comment: "ticket NET-123"},
+		{Domain: "web.lab.example.com", IP: "10.1.2.3"},
+	} {
+		if filter.matchDNSRecord(record) {
+			t.Errorf("expected %+v not to match", record)
+		}
+	}
+}
+
+func TestRecordFilterMatchCNAMERecord(t *testing.T) {
+	filter := &recordFilter{domainRegex: regexp.MustCompile(`^web\.`), target: "ingress.example.com"}
+
+	if !filter.matchCNAMERecord(pihole.CNAMERecord{Domain: "web.example.com", Target: "Ingress.example.com."}) {
+		t.Error("expected record to match regardless of target case and trailing dot")
+	}
+
+	if filter.matchCNAMERecord(pihole.CNAMERecord{Domain: "db.example.com", Target: "ingress.example.com"}) {
+		t.Error("expected record not to match the domain regex")
+	}
+
+	if !(&recordFilter{}).matchCNAMERecord(pihole.CNAMERecord{}) {
+		t.Error("expected an empty filter to match every record")
+	}
+}
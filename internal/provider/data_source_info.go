@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// versionInfo is a component version reported by /api/info/version
+type versionInfo struct {
+	Local struct {
+		Version string `json:"version"`
+	} `json:"local"`
+	Remote struct {
+		Version string `json:"version"`
+	} `json:"remote"`
+}
+
+// infoVersionResponse is the response of /api/info/version
+type infoVersionResponse struct {
+	Version struct {
+		Core   versionInfo `json:"core"`
+		Web    versionInfo `json:"web"`
+		FTL    versionInfo `json:"ftl"`
+		Docker struct {
+			Local  *string `json:"local"`
+			Remote *string `json:"remote"`
+		} `json:"docker"`
+	} `json:"version"`
+}
+
+// infoFTLResponse is the response of /api/info/ftl
+type infoFTLResponse struct {
+	FTL struct {
+		PID          int   `json:"pid"`
+		Uptime       int64 `json:"uptime"`
+		PrivacyLevel int   `json:"privacy_level"`
+	} `json:"ftl"`
+}
+
+// infoSystemResponse is the response of /api/info/system
+type infoSystemResponse struct {
+	System struct {
+		Uptime int64 `json:"uptime"`
+		Memory struct {
+			RAM struct {
+				Total       int64   `json:"total"`
+				Used        int64   `json:"used"`
+				PercentUsed float64 `json:"%used"`
+			} `json:"ram"`
+		} `json:"memory"`
+		CPU struct {
+			NProcs     int     `json:"nprocs"`
+			PercentCPU float64 `json:"%cpu"`
+			Load       struct {
+				Raw []float64 `json:"raw"`
+			} `json:"load"`
+		} `json:"cpu"`
+	} `json:"system"`
+}
+
+// infoDatabaseResponse is the response of /api/info/database
+type infoDatabaseResponse struct {
+	Size    int64 `json:"size"`
+	Queries int64 `json:"queries"`
+}
+
+// dataSourceInfo returns a schema resource for reading Pi-hole version and host information
+func dataSourceInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the versions of the Pi-hole components, FTL status and host system statistics",
+		ReadContext: dataSourceInfoRead,
+		Schema: map[string]*schema.Schema{
+			"core_version": {
+				Description: "Installed Pi-hole core version, e.g. `v6.0.4`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"core_latest_version": {
+				Description: "Latest available Pi-hole core version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"web_version": {
+				Description: "Installed Pi-hole web interface version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"web_latest_version": {
+				Description: "Latest available Pi-hole web interface version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ftl_version": {
+				Description: "Installed FTL version, e.g. `v6.0.4`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ftl_latest_version": {
+				Description: "Latest available FTL version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"docker_version": {
+				Description: "Pi-hole Docker image version, empty when Pi-hole does not run in the official image",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"docker_latest_version": {
+				Description: "Latest available Pi-hole Docker image version, empty when Pi-hole does not run in the official image",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"update_available": {
+				Description: "Whether a newer version of any installed component is available",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"ftl_pid": {
+				Description: "Process ID of FTL",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"ftl_uptime": {
+				Description: "Uptime of FTL in seconds",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"privacy_level": {
+				Description: "FTL privacy level, from `0` (show everything) to `3` (anonymous mode)",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"database_size": {
+				Description: "Size of the long-term query database in bytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"database_queries": {
+				Description: "Number of queries stored in the long-term query database",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"system_uptime": {
+				Description: "Uptime of the host in seconds",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"cpu_cores": {
+				Description: "Number of CPU cores of the host",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"cpu_percent": {
+				Description: "CPU utilization of the host in percent",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"load": {
+				Description: "1, 5 and 15 minute load averages of the host",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeFloat},
+			},
+			"memory_total": {
+				Description: "Total memory of the host in kilobytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_used": {
+				Description: "Used memory of the host in kilobytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_percent": {
+				Description: "Memory utilization of the host in percent",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceInfoRead reads the Pi-hole version, FTL, system and database information endpoints
+func dataSourceInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var version infoVersionResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/info/version", nil, &version); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	var ftl infoFTLResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/info/ftl", nil, &ftl); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	var system infoSystemResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/info/system", nil, &system); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	var database infoDatabaseResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/info/database", nil, &database); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	v := version.Version
+
+	var dockerVersion, dockerLatestVersion string
+	if v.Docker.Local != nil {
+		dockerVersion = *v.Docker.Local
+	}
+
+	if v.Docker.Remote != nil {
+		dockerLatestVersion = *v.Docker.Remote
+	}
+
+	updateAvailable := newerVersion(v.Core.Local.Version, v.Core.Remote.Version) ||
+		newerVersion(v.Web.Local.Version, v.Web.Remote.Version) ||
+		newerVersion(v.FTL.Local.Version, v.FTL.Remote.Version) ||
+		newerVersion(dockerVersion, dockerLatestVersion)
+
+	values := map[string]interface{}{
+		"core_version":          v.Core.Local.Version,
+		"core_latest_version":   v.Core.Remote.Version,
+		"web_version":           v.Web.Local.Version,
+		"web_latest_version":    v.Web.Remote.Version,
+		"ftl_version":           v.FTL.Local.Version,
+		"ftl_latest_version":    v.FTL.Remote.Version,
+		"docker_version":        dockerVersion,
+		"docker_latest_version": dockerLatestVersion,
+		"update_available":      updateAvailable,
+		"ftl_pid":               ftl.FTL.PID,
+		"ftl_uptime":            ftl.FTL.Uptime / 1000,
+		"privacy_level":         ftl.FTL.PrivacyLevel,
+		"database_size":         database.Size,
+		"database_queries":      database.Queries,
+		"system_uptime":         system.System.Uptime,
+		"cpu_cores":             system.System.CPU.NProcs,
+		"cpu_percent":           system.System.CPU.PercentCPU,
+		"load":                  system.System.CPU.Load.Raw,
+		"memory_total":          system.System.Memory.RAM.Total,
+		"memory_used":           system.System.Memory.RAM.Used,
+		"memory_percent":        system.System.Memory.RAM.PercentUsed,
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(client.api.baseURL)
+
+	return diags
+}
+
+// newerVersion reports whether a latest version is known and differs from the local version
+func newerVersion(local string, latest string) bool {
+	return local != "" && latest != "" && !strings.EqualFold(local, latest)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccInfoData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_info" "info" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.pihole_info.info", "ftl_version", regexp.MustCompile(`^v?\d+\.`)),
+					resource.TestMatchResourceAttr("data.pihole_info.info", "core_version", regexp.MustCompile(`^v?\d+\.`)),
+					resource.TestMatchResourceAttr("data.pihole_info.info", "ftl_pid", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttr("data.pihole_info.info", "load.#", "3"),
+					resource.TestCheckResourceAttrSet("data.pihole_info.info", "privacy_level"),
+					resource.TestCheckResourceAttrSet("data.pihole_info.info", "memory_total"),
+				),
+			},
+		},
+	})
+}
+
+func TestNewerVersion(t *testing.T) {
+	cases := []struct {
+		local  string
+		latest string
+		want   bool
+	}{
+		{"v6.0.4", "v6.0.4", false},
+		{"v6.0.4", "v6.0.5", true},
+		{"", "v6.0.5", false},
+		{"v6.0.4", "", false},
+		{"V6.0.4", "v6.0.4", false},
+	}
+
+	for _, c := range cases {
+		if got := newerVersion(c.local, c.latest); got != c.want {
+			t.Errorf("newerVersion(%q, %q) = %t, want %t", c.local, c.latest, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// diagnosisMessage is an FTL diagnosis message
+type diagnosisMessage struct {
+	ID        int64   `json:"id"`
+	Timestamp float64 `json:"timestamp"`
+	Type      string  `json:"type"`
+	Plain     string  `json:"plain"`
+}
+
+// messagesResponse is the response of /api/info/messages
+type messagesResponse struct {
+	Messages []diagnosisMessage `json:"messages"`
+}
+
+// dataSourceMessages returns a schema resource for listing the FTL diagnosis messages
+func dataSourceMessages() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the FTL diagnosis messages shown on the Pi-hole dashboard, such as rate limiting, dnsmasq warnings and invalid regular expressions",
+		ReadContext: dataSourceMessagesRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:  "Only return messages of this type, e.g. `REGEX`, `RATE_LIMIT`, `DNSMASQ_WARN` or `LOAD`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"ids": {
+				Description: "IDs of the matching messages",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"messages": {
+				Description: "Matching messages, oldest first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the message",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Type of the message",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"timestamp": {
+							Description: "RFC 3339 time the message was emitted",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"message": {
+							Description: "Text of the message",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceMessagesRead lists the diagnosis messages matching the type filter
+func dataSourceMessagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	messageType := d.Get("type").(string)
+
+	var res messagesResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/info/messages", nil, &res); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	ids := make([]int64, 0, len(res.Messages))
+	list := make([]map[string]interface{}, 0, len(res.Messages))
+	hash := sha256.New()
+	hash.Write([]byte(messageType))
+
+	for _, message := range res.Messages {
+		if messageType != "" && !strings.EqualFold(message.Type, messageType) {
+			continue
+		}
+
+		hash.Write([]byte{0})
+		hash.Write([]byte(strconv.FormatInt(message.ID, 10)))
+
+		ids = append(ids, message.ID)
+		list = append(list, map[string]interface{}{
+			"id":        message.ID,
+			"type":      message.Type,
+			"timestamp": formatQueryTime(message.Timestamp),
+			"message":   message.Plain,
+		})
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("messages", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMessagesData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_messages" "all" {}
+
+					data "pihole_messages" "missing" {
+					  type = "NO_SUCH_TYPE"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_messages.all", "messages.#"),
+					resource.TestCheckResourceAttr("data.pihole_messages.missing", "messages.#", "0"),
+					resource.TestCheckResourceAttr("data.pihole_messages.missing", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// networkTableMaxDevices is requested as max_devices of /api/network/devices, which has no option to return the whole table,
+// so the filters and max_results are applied to every device of the network table
+const networkTableMaxDevices = math.MaxInt32
+
+// networkDevice is a device of the FTL network table
+type networkDevice struct {
+	ID         int64                  `json:"id"`
+	HWAddr     string                 `json:"hwaddr"`
+	Interface  string                 `json:"interface"`
+	FirstSeen  int64                  `json:"firstSeen"`
+	LastQuery  int64                  `json:"lastQuery"`
+	NumQueries int64                  `json:"numQueries"`
+	MACVendor  *string                `json:"macVendor"`
+	IPs        []networkDeviceAddress `json:"ips"`
+}
+
+// networkDeviceAddress is an IP address of a device of the FTL network table
+type networkDeviceAddress struct {
+	IP       string  `json:"ip"`
+	Name     *string `json:"name"`
+	LastSeen int64   `json:"lastSeen"`
+}
+
+// lastSeen returns the Unix time the device last sent a query or was seen with any of its addresses
+func (n networkDevice) lastSeen() int64 {
+	lastSeen := n.LastQuery
+	for _, ip := range n.IPs {
+		lastSeen = max(lastSeen, ip.LastSeen)
+	}
+
+	return lastSeen
+}
+
+// networkDevicesResponse is the response of /api/network/devices
+type networkDevicesResponse struct {
+	Devices []networkDevice `json:"devices"`
+}
+
+// dataSourceNetworkDevices returns a schema resource for listing the devices of the FTL network table
+func dataSourceNetworkDevices() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the devices Pi-hole has seen on the network, from the FTL network table",
+		ReadContext: dataSourceNetworkDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"last_seen_within": {
+				Description:      "Only return devices seen within this duration, e.g. `24h` or `30m`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateDuration,
+			},
+			"interface": {
+				Description:  "Only return devices seen on this network interface, e.g. `eth0`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"max_results": {
+				Description:      "Maximum number of matching devices to return, most recently seen first. Defaults to `1000`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1000,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"max_addresses": {
+				Description:      "Maximum number of IP addresses to return per device, most recently seen first. Defaults to `100`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"devices": {
+				Description: "Matching devices",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the device in the network table",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"mac": {
+							Description: "Hardware address of the device, `ip-<address>` for devices without a known hardware address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vendor": {
+							Description: "Vendor of the hardware address, empty when unknown",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"interface": {
+							Description: "Network interface the device was seen on",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"first_seen": {
+							Description: "RFC 3339 time the device was first seen",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_query": {
+							Description: "RFC 3339 time of the last query of the device, empty when it never sent a query",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_seen": {
+							Description: "RFC 3339 time the device last sent a query or was seen with any of its addresses",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"query_count": {
+							Description: "Number of queries sent by the device",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"addresses": {
+							Description: "IP addresses of the device",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ip": {
+										Description: "IP address",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"hostname": {
+										Description: "Hostname of the address, empty when unknown",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"last_seen": {
+										Description: "RFC 3339 time the address was last seen",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceNetworkDevicesRead lists the devices of the network table matching the filters
+func dataSourceNetworkDevicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var seenSince int64
+	if value := d.Get("last_seen_within").(string); value != "" {
+		within, err := time.ParseDuration(value)
+		if err != nil {
+			return diag.Errorf("invalid last_seen_within: %s", err)
+		}
+
+		seenSince = time.Now().Add(-within).Unix()
+	}
+
+	networkInterface := d.Get("interface").(string)
+
+	query := url.Values{}
+	query.Set("max_devices", strconv.Itoa(networkTableMaxDevices))
+	query.Set("max_addresses", strconv.Itoa(d.Get("max_addresses").(int)))
+
+	var res networkDevicesResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/network/devices?"+query.Encode(), nil, &res); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	devices := make([]networkDevice, 0, len(res.Devices))
+	for _, device := range res.Devices {
+		if device.lastSeen() >= seenSince && (networkInterface == "" || device.Interface == networkInterface) {
+			devices = append(devices, device)
+		}
+	}
+
+	sort.SliceStable(devices, func(i, j int) bool {
+		return devices[i].lastSeen() > devices[j].lastSeen()
+	})
+
+	if maxResults := d.Get("max_results").(int); len(devices) > maxResults {
+		devices = devices[:maxResults]
+	}
+
+	list := make([]map[string]interface{}, 0, len(devices))
+	hash := sha256.New()
+
+	for _, device := range devices {
+		hash.Write([]byte(device.HWAddr))
+		hash.Write([]byte{0})
+
+		addresses := make([]map[string]interface{}, len(device.IPs))
+		for i, ip := range device.IPs {
+			addresses[i] = map[string]interface{}{
+				"ip":        ip.IP,
+				"hostname":  stringValue(ip.Name),
+				"last_seen": formatUnixTime(ip.LastSeen),
+			}
+		}
+
+		list = append(list, map[string]interface{}{
+			"id":          device.ID,
+			"mac":         device.HWAddr,
+			"vendor":      stringValue(device.MACVendor),
+			"interface":   device.Interface,
+			"first_seen":  formatUnixTime(device.FirstSeen),
+			"last_query":  formatUnixTime(device.LastQuery),
+			"last_seen":   formatUnixTime(device.lastSeen()),
+			"query_count": device.NumQueries,
+			"addresses":   addresses,
+		})
+	}
+
+	if err := d.Set("devices", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
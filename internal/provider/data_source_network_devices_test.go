@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccNetworkDevicesData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_network_devices" "all" {}
+
+					data "pihole_network_devices" "filtered" {
+					  last_seen_within = "1h"
+					  interface        = "missing0"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_network_devices.all", "devices.#"),
+					resource.TestCheckResourceAttr("data.pihole_network_devices.filtered", "devices.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestNetworkDeviceLastSeen(t *testing.T) {
+	var res networkDevicesResponse
+	if err := json.Unmarshal([]byte(`{"devices":[
+		{"hwaddr":"aa:bb:cc:dd:ee:ff","lastQuery":100,"ips":[{"ip":"192.168.1.2","lastSeen":50},{"ip":"192.168.1.3","lastSeen":200}]},
+		{"hwaddr":"ip-192.168.1.4","lastQuery":300,"ips":[]}
+	]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []int64{200, 300} {
+		if got := res.Devices[i].lastSeen(); got != want {
+			t.Errorf("device %d: lastSeen() = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestNetworkDevicesDataLimitsMatchingDevices(t *testing.T) {
+	var requests []url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query())
+
+		_, _ = w.Write([]byte(`{"devices":[
+			{"hwaddr":"aa:bb:cc:dd:ee:01","interface":"wlan0","lastQuery":400,"ips":[]},
+			{"hwaddr":"aa:bb:cc:dd:ee:02","interface":"eth0","lastQuery":100,"ips":[]},
+			{"hwaddr":"aa:bb:cc:dd:ee:03","interface":"eth0","lastQuery":300,"ips":[]},
+			{"hwaddr":"aa:bb:cc:dd:ee:04","interface":"eth0","lastQuery":200,"ips":[]}
+		]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	client := &Client{api: &apiClient{baseURL: ts.URL, http: ts.Client()}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceNetworkDevices().Schema, map[string]interface{}{
+		"interface":     "eth0",
+		"max_results":   2,
+		"max_addresses": 5,
+	})
+
+	if diags := dataSourceNetworkDevicesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if len(requests) != 1 || requests[0].Get("max_devices") != strconv.Itoa(networkTableMaxDevices) || requests[0].Get("max_addresses") != "5" {
+		t.Fatalf("expected the whole network table to be requested, got %v", requests)
+	}
+
+	devices := d.Get("devices").([]interface{})
+	if len(devices) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(devices))
+	}
+
+	for i, want := range []string{"aa:bb:cc:dd:ee:03", "aa:bb:cc:dd:ee:04"} {
+		if got := devices[i].(map[string]interface{})["mac"]; got != want {
+			t.Errorf("device %d: mac = %v, want %s", i, got, want)
+		}
+	}
+}
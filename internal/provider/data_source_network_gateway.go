@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networkGatewayResponse is the response of /api/network/gateway
+type networkGatewayResponse struct {
+	Gateway []struct {
+		Family    string   `json:"family"`
+		Interface string   `json:"interface"`
+		Address   string   `json:"address"`
+		Local     []string `json:"local"`
+	} `json:"gateway"`
+}
+
+// dataSourceNetworkGateway returns a schema resource for reading the default gateways of the Pi-hole host
+func dataSourceNetworkGateway() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the IPv4 and IPv6 default gateways of the Pi-hole host",
+		ReadContext: dataSourceNetworkGatewayRead,
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Description: "IPv4 default gateway, empty when the host has none",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"interface": {
+				Description: "Interface of the IPv4 default gateway",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"local_addresses": {
+				Description: "IPv4 addresses of the host on the interface of the default gateway",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ipv6_address": {
+				Description: "IPv6 default gateway, empty when the host has none",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ipv6_interface": {
+				Description: "Interface of the IPv6 default gateway",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ipv6_local_addresses": {
+				Description: "IPv6 addresses of the host on the interface of the default gateway",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// dataSourceNetworkGatewayRead reads the first IPv4 and IPv6 default gateways of the host
+func dataSourceNetworkGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var res networkGatewayResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/network/gateway", nil, &res); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	values := map[string]interface{}{
+		"address":              "",
+		"interface":            "",
+		"local_addresses":      []string{},
+		"ipv6_address":         "",
+		"ipv6_interface":       "",
+		"ipv6_local_addresses": []string{},
+	}
+
+	// Iterate backwards so the first gateway of each family wins
+	for i := len(res.Gateway) - 1; i >= 0; i-- {
+		gateway := res.Gateway[i]
+
+		prefix := ""
+		switch gateway.Family {
+		case "inet":
+		case "inet6":
+			prefix = "ipv6_"
+		default:
+			continue
+		}
+
+		values[prefix+"address"] = gateway.Address
+		values[prefix+"interface"] = gateway.Interface
+		values[prefix+"local_addresses"] = gateway.Local
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(client.api.baseURL)
+
+	return diags
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetworkGatewayData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_network_gateway" "gateway" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_network_gateway.gateway", "address"),
+					resource.TestCheckResourceAttrSet("data.pihole_network_gateway.gateway", "interface"),
+				),
+			},
+		},
+	})
+}
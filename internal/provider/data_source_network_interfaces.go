@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networkInterface is a network interface of the Pi-hole host
+type networkInterface struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	State   string   `json:"state"`
+	Carrier bool     `json:"carrier"`
+	Speed   *int64   `json:"speed"`
+	MTU     int64    `json:"mtu"`
+	Address string   `json:"address"`
+	Flags   []string `json:"flags"`
+	Stats   struct {
+		RXBytes   ftlCounter `json:"rx_bytes"`
+		TXBytes   ftlCounter `json:"tx_bytes"`
+		RXPackets ftlCounter `json:"rx_packets"`
+		TXPackets ftlCounter `json:"tx_packets"`
+		RXErrors  ftlCounter `json:"rx_errors"`
+		TXErrors  ftlCounter `json:"tx_errors"`
+	} `json:"stats"`
+	Addresses []struct {
+		Family    string `json:"family"`
+		Scope     string `json:"scope"`
+		Address   string `json:"address"`
+		PrefixLen int64  `json:"prefixlen"`
+	} `json:"addresses"`
+}
+
+// networkInterfacesResponse is the response of /api/network/interfaces
+type networkInterfacesResponse struct {
+	Interfaces []networkInterface `json:"interfaces"`
+}
+
+// ftlCounter is a traffic counter reported by FTL, either as a raw number or as a {"value":1.5,"unit":"M"} object scaled to a unit prefix.
+// Scaled counters are rounded by FTL, so their value is approximate.
+type ftlCounter int64
+
+// ftlCounterPrefixes are the unit prefixes of scaled counters, in order of their exponent
+const ftlCounterPrefixes = "KMGTPE"
+
+func (c *ftlCounter) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*c = ftlCounter(number)
+		return nil
+	}
+
+	var scaled struct {
+		Value float64 `json:"value"`
+		Unit  string  `json:"unit"`
+	}
+
+	if err := json.Unmarshal(data, &scaled); err != nil {
+		return fmt.Errorf("expected a number or a value with unit, got %s", data)
+	}
+
+	scale, err := ftlCounterScale(scaled.Unit)
+	if err != nil {
+		return err
+	}
+
+	*c = ftlCounter(math.Round(scaled.Value * scale))
+
+	return nil
+}
+
+// ftlCounterScale returns the multiplier of a counter unit, a decimal prefix like K or KB or a binary prefix like Ki or KiB
+func ftlCounterScale(unit string) (float64, error) {
+	unit = strings.TrimSpace(unit)
+	if unit == "" || unit == "B" || strings.EqualFold(unit, "bytes") {
+		return 1, nil
+	}
+
+	prefix := strings.TrimSuffix(unit, "B")
+
+	base := 1000.0
+	if binary, ok := strings.CutSuffix(prefix, "i"); ok {
+		prefix, base = binary, 1024
+	}
+
+	exponent := strings.Index(ftlCounterPrefixes, strings.ToUpper(prefix)) + 1
+	if len(prefix) != 1 || exponent == 0 {
+		return 0, fmt.Errorf("unknown counter unit %q", unit)
+	}
+
+	return math.Pow(base, float64(exponent)), nil
+}
+
+// dataSourceNetworkInterfaces returns a schema resource for listing the network interfaces of the Pi-hole host
+func dataSourceNetworkInterfaces() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the network interfaces of the Pi-hole host with their addresses, state and traffic counters. Counters Pi-hole reports scaled to a unit, e.g. `1.5 M`, are approximate.",
+		ReadContext: dataSourceNetworkInterfacesRead,
+		Schema: map[string]*schema.Schema{
+			"interfaces": {
+				Description: "Network interfaces of the host",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the interface, e.g. `eth0`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Link type of the interface, e.g. `ether` or `loopback`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "Operational state of the interface, e.g. `up` or `down`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"carrier": {
+							Description: "Whether the interface has a carrier",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"speed": {
+							Description: "Link speed in Mbit/s, `0` when unknown",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"mtu": {
+							Description: "MTU of the interface",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"mac": {
+							Description: "Hardware address of the interface",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"flags": {
+							Description: "Interface flags, e.g. `up`, `broadcast` or `multicast`",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"rx_bytes": {
+							Description: "Bytes received",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"tx_bytes": {
+							Description: "Bytes sent",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"rx_packets": {
+							Description: "Packets received",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"tx_packets": {
+							Description: "Packets sent",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"rx_errors": {
+							Description: "Receive errors",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"tx_errors": {
+							Description: "Transmit errors",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"addresses": {
+							Description: "IP addresses of the interface",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"address": {
+										Description: "IP address",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"prefix_length": {
+										Description: "Prefix length of the network",
+										Type:        schema.TypeInt,
+										Computed:    true,
+									},
+									"family": {
+										Description: "`inet` for IPv4 or `inet6` for IPv6",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"scope": {
+										Description: "Scope of the address, e.g. `global`, `link` or `host`",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceNetworkInterfacesRead lists the network interfaces of the host
+func dataSourceNetworkInterfacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var res networkInterfacesResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/network/interfaces", nil, &res); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	list := make([]map[string]interface{}, len(res.Interfaces))
+	hash := sha256.New()
+
+	for i, iface := range res.Interfaces {
+		hash.Write([]byte(iface.Name))
+		hash.Write([]byte{0})
+
+		addresses := make([]map[string]interface{}, len(iface.Addresses))
+		for j, address := range iface.Addresses {
+			addresses[j] = map[string]interface{}{
+				"address":       address.Address,
+				"prefix_length": address.PrefixLen,
+				"family":        address.Family,
+				"scope":         address.Scope,
+			}
+		}
+
+		var speed int64
+		if iface.Speed != nil && *iface.Speed > 0 {
+			speed = *iface.Speed
+		}
+
+		list[i] = map[string]interface{}{
+			"name":       iface.Name,
+			"type":       iface.Type,
+			"state":      iface.State,
+			"carrier":    iface.Carrier,
+			"speed":      speed,
+			"mtu":        iface.MTU,
+			"mac":        iface.Address,
+			"flags":      iface.Flags,
+			"rx_bytes":   int64(iface.Stats.RXBytes),
+			"tx_bytes":   int64(iface.Stats.TXBytes),
+			"rx_packets": int64(iface.Stats.RXPackets),
+			"tx_packets": int64(iface.Stats.TXPackets),
+			"rx_errors":  int64(iface.Stats.RXErrors),
+			"tx_errors":  int64(iface.Stats.TXErrors),
+			"addresses":  addresses,
+		}
+	}
+
+	if err := d.Set("interfaces", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
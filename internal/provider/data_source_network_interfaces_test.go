@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetworkInterfacesData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_network_interfaces" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.pihole_network_interfaces.all", "interfaces.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_network_interfaces.all", "interfaces.*", map[string]string{"name": "lo"}),
+				),
+			},
+		},
+	})
+}
+
+func TestFTLCounterUnmarshal(t *testing.T) {
+	cases := map[string]int64{
+		`12345`:                          12345,
+		`{"value":1.5,"unit":"K"}`:       1500,
+		`{"value":2,"unit":"MB"}`:        2000000,
+		`{"value":0.25,"unit":"G"}`:      250000000,
+		`{"value":42,"unit":""}`:         42,
+		`{"value":3.5,"unit":" T"}`:      3500000000000,
+		`{"value":512.4,"unit":"bytes"}`: 512,
+		`{"value":1.5,"unit":"KiB"}`:     1536,
+		`{"value":2,"unit":"Mi"}`:        2097152,
+		`{"value":7,"unit":"k"}`:         7000,
+	}
+
+	for input, want := range cases {
+		var counter ftlCounter
+		if err := json.Unmarshal([]byte(input), &counter); err != nil {
+			t.Errorf("%s: unexpected error: %s", input, err)
+			continue
+		}
+
+		if int64(counter) != want {
+			t.Errorf("%s: got %d, want %d", input, counter, want)
+		}
+	}
+
+	for _, input := range []string{`"many"`, `{"value":1,"unit":"X"}`, `{"value":1,"unit":"KMB"}`, `{"value":1,"unit":"iB"}`} {
+		var counter ftlCounter
+		if err := json.Unmarshal([]byte(input), &counter); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networkRoutesResponse is the response of /api/network/routes
+type networkRoutesResponse struct {
+	Routes []struct {
+		Table    int64  `json:"table"`
+		Family   string `json:"family"`
+		Protocol string `json:"protocol"`
+		Scope    string `json:"scope"`
+		Type     string `json:"type"`
+		Dst      string `json:"dst"`
+		Gateway  string `json:"gateway"`
+		OIF      string `json:"oif"`
+		PrefSrc  string `json:"prefsrc"`
+		Priority int64  `json:"priority"`
+	} `json:"routes"`
+}
+
+// dataSourceNetworkRoutes returns a schema resource for listing the routing table of the Pi-hole host
+func dataSourceNetworkRoutes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the routing table of the Pi-hole host",
+		ReadContext: dataSourceNetworkRoutesRead,
+		Schema: map[string]*schema.Schema{
+			"routes": {
+				Description: "Routes of the host",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination": {
+							Description: "Destination network of the route, `default` for default routes",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"gateway": {
+							Description: "Gateway of the route, empty for directly connected networks",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"interface": {
+							Description: "Outgoing interface of the route",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"preferred_source": {
+							Description: "Preferred source address of the route, empty when unset",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"family": {
+							Description: "`inet` for IPv4 or `inet6` for IPv6",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"protocol": {
+							Description: "Origin of the route, e.g. `kernel`, `boot` or `dhcp`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"scope": {
+							Description: "Scope of the route, e.g. `universe` or `link`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Type of the route, e.g. `unicast` or `local`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"table": {
+							Description: "Routing table of the route, `254` for the main table",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"priority": {
+							Description: "Metric of the route",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceNetworkRoutesRead lists the routes of the host
+func dataSourceNetworkRoutesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var res networkRoutesResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/network/routes", nil, &res); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	list := make([]map[string]interface{}, len(res.Routes))
+	hash := sha256.New()
+
+	for i, route := range res.Routes {
+		for _, value := range []string{route.Dst, route.Gateway, route.OIF} {
+			hash.Write([]byte(value))
+			hash.Write([]byte{0})
+		}
+
+		list[i] = map[string]interface{}{
+			"destination":      route.Dst,
+			"gateway":          route.Gateway,
+			"interface":        route.OIF,
+			"preferred_source": route.PrefSrc,
+			"family":           route.Family,
+			"protocol":         route.Protocol,
+			"scope":            route.Scope,
+			"type":             route.Type,
+			"table":            route.Table,
+			"priority":         route.Priority,
+		}
+	}
+
+	if err := d.Set("routes", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
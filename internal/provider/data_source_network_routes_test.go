@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetworkRoutesData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_network_routes" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.pihole_network_routes.all", "routes.#", regexp.MustCompile(`^[1-9]\d*$`)),
+				),
+			},
+		},
+	})
+}
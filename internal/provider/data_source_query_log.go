@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// queryLogPageSize is the number of queries requested per page of /api/queries
+const queryLogPageSize = 500
+
+// queryStatuses are the FTL query statuses, mapped to whether they mark a blocked query
+var queryStatuses = map[string]bool{
+	"UNKNOWN":                false,
+	"GRAVITY":                true,
+	"FORWARDED":              false,
+	"CACHE":                  false,
+	"REGEX":                  true,
+	"DENYLIST":               true,
+	"EXTERNAL_BLOCKED_IP":    true,
+	"EXTERNAL_BLOCKED_NULL":  true,
+	"EXTERNAL_BLOCKED_NXRA":  true,
+	"GRAVITY_CNAME":          true,
+	"REGEX_CNAME":            true,
+	"DENYLIST_CNAME":         true,
+	"RETRIED":                false,
+	"RETRIED_DNSSEC":         false,
+	"IN_PROGRESS":            false,
+	"DBBUSY":                 true,
+	"SPECIAL_DOMAIN":         true,
+	"CACHE_STALE":            false,
+	"EXTERNAL_BLOCKED_EDE15": true,
+}
+
+// queryLogEntry is a query of the FTL query log
+type queryLogEntry struct {
+	ID       int64   `json:"id"`
+	Time     float64 `json:"time"`
+	Type     string  `json:"type"`
+	Status   string  `json:"status"`
+	DNSSEC   string  `json:"dnssec"`
+	Domain   string  `json:"domain"`
+	Upstream *string `json:"upstream"`
+	Reply    struct {
+		Type string  `json:"type"`
+		Time float64 `json:"time"`
+	} `json:"reply"`
+	Client struct {
+		IP   string  `json:"ip"`
+		Name *string `json:"name"`
+	} `json:"client"`
+	ListID *int64 `json:"list_id"`
+}
+
+// queryLogResponse is a page of /api/queries
+type queryLogResponse struct {
+	Queries         []queryLogEntry `json:"queries"`
+	Cursor          *int64          `json:"cursor"`
+	RecordsFiltered int64           `json:"recordsFiltered"`
+}
+
+// queryStatusNames returns the sorted FTL query statuses
+func queryStatusNames() []string {
+	names := make([]string, 0, len(queryStatuses))
+	for name := range queryStatuses {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// dataSourceQueryLog returns a schema resource for searching the FTL query log
+func dataSourceQueryLog() *schema.Resource {
+	return &schema.Resource{
+		Description: "Searches the FTL query log, newest queries first. Queries within a `from` and `until` time window are read from the long-term database.",
+		ReadContext: dataSourceQueryLogRead,
+		Schema: withTimeWindow(map[string]*schema.Schema{
+			"client": {
+				Description:  "Only return queries of this client IP address or hostname, `*` matches any characters.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"domain": {
+				Description:  "Only return queries for this domain, `*` matches any characters, e.g. `*.example.com`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"type": {
+				Description:  "Only return queries of this type, e.g. `A`, `AAAA` or `HTTPS`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"status": {
+				Description:  "Only return queries with this FTL status, one of `CACHE`, `CACHE_STALE`, `DBBUSY`, `DENYLIST`, `DENYLIST_CNAME`, `EXTERNAL_BLOCKED_EDE15`, `EXTERNAL_BLOCKED_IP`, `EXTERNAL_BLOCKED_NULL`, `EXTERNAL_BLOCKED_NXRA`, `FORWARDED`, `GRAVITY`, `GRAVITY_CNAME`, `IN_PROGRESS`, `REGEX`, `REGEX_CNAME`, `RETRIED`, `RETRIED_DNSSEC`, `SPECIAL_DOMAIN` or `UNKNOWN`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(queryStatusNames(), false),
+			},
+			"blocked": {
+				Description: "Only return blocked queries when `true`, e.g. by gravity, regex or denylist entries or an upstream server, or only queries which were not blocked when `false`. " +
+					"Pi-hole filters by a single status, so queries are matched by the provider, reading at most `max_scanned` queries.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"status"},
+			},
+			"upstream": {
+				Description:  "Only return queries forwarded to this upstream server, e.g. `1.1.1.1#53`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"max_results": {
+				Description:      "Maximum number of queries to return, the query log is paged through until this many queries are read. Defaults to `100`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 100000)),
+			},
+			"max_scanned": {
+				Description:      "Maximum number of queries read from the query log to find the queries matching `blocked`, a warning is returned when it is reached before `max_results` matching queries are found. Defaults to `10000`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10000,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 1000000)),
+			},
+			"total": {
+				Description: "Number of queries matching the filters, which may exceed `max_results`. With `blocked`, Pi-hole cannot count the matching queries, so this is the number of matching queries among those read.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"queries": {
+				Description: "Matching queries, newest first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the query in the query log",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"timestamp": {
+							Description: "RFC 3339 time of the query",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Query type, e.g. `A`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "FTL status of the query, e.g. `GRAVITY` or `FORWARDED`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Queried domain",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client_ip": {
+							Description: "IP address of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client_name": {
+							Description: "Hostname of the client, empty when unknown",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"upstream": {
+							Description: "Upstream server the query was forwarded to, empty when not forwarded",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"reply_type": {
+							Description: "Type of the reply, e.g. `IP`, `NXDOMAIN` or `BLOB`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"reply_time": {
+							Description: "Time until the reply was sent in milliseconds, negative when unknown",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"dnssec": {
+							Description: "DNSSEC status of the reply",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"list_id": {
+							Description: "ID of the domain list entry or adlist that blocked or allowed the query, `0` when none",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+// dataSourceQueryLogRead searches the query log with the configured filters
+func dataSourceQueryLogRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	window, err := newTimeWindow(d, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filters := url.Values{}
+	if window != nil {
+		window.encode(filters)
+
+		// Queries older than the in-memory log are only found in the long-term database
+		filters.Set("disk", "true")
+	}
+
+	if value := d.Get("client").(string); value != "" {
+		if net.ParseIP(value) != nil {
+			filters.Set("client_ip", value)
+		} else {
+			filters.Set("client_name", value)
+		}
+	}
+
+	for _, attribute := range []string{"domain", "type", "status", "upstream"} {
+		if value := d.Get(attribute).(string); value != "" {
+			filters.Set(attribute, value)
+		}
+	}
+
+	var match func(q queryLogEntry) bool
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("blocked").IsNull() {
+		blocked := config.GetAttr("blocked").True()
+
+		match = func(q queryLogEntry) bool {
+			return queryStatuses[q.Status] == blocked
+		}
+	}
+
+	maxResults, maxScanned := d.Get("max_results").(int), d.Get("max_scanned").(int)
+
+	queries, total, complete, err := readQueryLog(ctx, client.api, filters, match, maxResults, maxScanned, queryLogPageSize)
+	if err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	if !complete {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Query log search stopped at max_scanned",
+			Detail: fmt.Sprintf("Only %d of %d requested queries matching blocked were found in the %d queries read. "+
+				"Narrow the search with a time window or other filters, or increase max_scanned.", len(queries), maxResults, maxScanned),
+			AttributePath: cty.GetAttrPath("max_scanned"),
+		})
+	}
+
+	list := make([]map[string]interface{}, len(queries))
+	hash := sha256.New()
+	hash.Write([]byte(filters.Encode()))
+	if match != nil {
+		hash.Write([]byte("&blocked=" + strconv.FormatBool(d.Get("blocked").(bool))))
+	}
+
+	for i, q := range queries {
+		hash.Write([]byte{0})
+		hash.Write([]byte(strconv.FormatInt(q.ID, 10)))
+
+		entry := map[string]interface{}{
+			"id":          q.ID,
+			"timestamp":   formatQueryTime(q.Time),
+			"type":        q.Type,
+			"status":      q.Status,
+			"domain":      q.Domain,
+			"client_ip":   q.Client.IP,
+			"client_name": "",
+			"upstream":    "",
+			"reply_type":  q.Reply.Type,
+			"reply_time":  q.Reply.Time,
+			"dnssec":      q.DNSSEC,
+			"list_id":     0,
+		}
+
+		if q.Client.Name != nil {
+			entry["client_name"] = *q.Client.Name
+		}
+
+		if q.Upstream != nil {
+			entry["upstream"] = *q.Upstream
+		}
+
+		if q.ListID != nil {
+			entry["list_id"] = *q.ListID
+		}
+
+		list[i] = entry
+	}
+
+	if err := d.Set("queries", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("total", total); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
+
+// readQueryLog pages through /api/queries until maxResults queries are read or the log is exhausted.
+// The cursor returned with the first page pins later pages to the same snapshot of the log.
+// When match is set, only matching queries are returned and counted in the total, and the log is read until maxResults of them are found
+// or maxScanned queries were read, in which case complete is false.
+func readQueryLog(ctx context.Context, api *apiClient, filters url.Values, match func(q queryLogEntry) bool, maxResults int, maxScanned int, pageSize int) (queries []queryLogEntry, total int64, complete bool, err error) {
+	var read int64
+	var cursor *int64
+
+	for len(queries) < maxResults {
+		length := min(pageSize, maxResults-len(queries))
+		if match != nil {
+			if read >= int64(maxScanned) {
+				return queries, int64(len(queries)), false, nil
+			}
+
+			length = min(pageSize, maxScanned-int(read))
+		}
+
+		query := url.Values{}
+		for key, values := range filters {
+			query[key] = values
+		}
+
+		query.Set("start", strconv.FormatInt(read, 10))
+		query.Set("length", strconv.Itoa(length))
+
+		if cursor != nil {
+			query.Set("cursor", strconv.FormatInt(*cursor, 10))
+		}
+
+		var page queryLogResponse
+		if err := api.do(ctx, http.MethodGet, "/api/queries?"+query.Encode(), nil, &page); err != nil {
+			return nil, 0, false, err
+		}
+
+		if cursor == nil {
+			cursor = page.Cursor
+			total = page.RecordsFiltered
+		}
+
+		read += int64(len(page.Queries))
+
+		if match == nil {
+			queries = append(queries, page.Queries...)
+		} else {
+			for _, q := range page.Queries {
+				if match(q) {
+					queries = append(queries, q)
+				}
+			}
+		}
+
+		if len(page.Queries) < length || (total > 0 && read >= total) {
+			break
+		}
+	}
+
+	if match != nil {
+		total = int64(len(queries))
+	}
+
+	if len(queries) > maxResults {
+		queries = queries[:maxResults]
+	}
+
+	return queries, total, true, nil
+}
+
+// formatQueryTime formats the fractional Unix timestamp of a query as RFC 3339 in UTC
+func formatQueryTime(seconds float64) string {
+	whole, fraction := math.Modf(seconds)
+
+	return time.Unix(int64(whole), int64(fraction*1e9)).UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccQueryLogData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_query_log" "all" {
+					  max_results = 5
+					}
+
+					data "pihole_query_log" "blocked" {
+					  domain      = "*.example.com"
+					  blocked     = true
+					  from        = "2024-01-01T00:00:00Z"
+					  max_results = 5
+					}
+
+					data "pihole_query_log" "gravity" {
+					  status      = "GRAVITY"
+					  max_results = 5
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.pihole_query_log.all", "queries.#", regexp.MustCompile(`^[0-5]$`)),
+					resource.TestCheckResourceAttrSet("data.pihole_query_log.all", "total"),
+					resource.TestMatchResourceAttr("data.pihole_query_log.blocked", "queries.#", regexp.MustCompile(`^[0-5]$`)),
+					resource.TestMatchResourceAttr("data.pihole_query_log.gravity", "queries.#", regexp.MustCompile(`^[0-5]$`)),
+				),
+			},
+			{
+				Config: `
+					data "pihole_query_log" "invalid" {
+					  status = "BLOCKED"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`expected status to be one of`),
+			},
+		},
+	})
+}
+
+func TestReadQueryLogPaginates(t *testing.T) {
+	const logSize = 7
+
+	var requests []url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, query)
+
+		start, _ := strconv.Atoi(query.Get("start"))
+		length, _ := strconv.Atoi(query.Get("length"))
+
+		page := queryLogResponse{RecordsFiltered: logSize}
+		for id := start; id < start+length && id < logSize; id++ {
+			page.Queries = append(page.Queries, queryLogEntry{ID: int64(id), Domain: query.Get("domain")})
+		}
+
+		if query.Get("cursor") == "" {
+			cursor := int64(1000)
+			page.Cursor = &cursor
+		}
+
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	api := &apiClient{baseURL: ts.URL, http: ts.Client()}
+
+	queries, total, _, err := readQueryLog(context.Background(), api, url.Values{"domain": {"foo.com"}}, nil, 5, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != logSize {
+		t.Errorf("total = %d, want %d", total, logSize)
+	}
+
+	if len(queries) != 5 || queries[4].ID != 4 || queries[0].Domain != "foo.com" {
+		t.Fatalf("unexpected queries: %+v", queries)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+
+	for i, want := range []struct{ start, length, cursor string }{{"0", "2", ""}, {"2", "2", "1000"}, {"4", "1", "1000"}} {
+		got := requests[i]
+		if got.Get("start") != want.start || got.Get("length") != want.length || got.Get("cursor") != want.cursor {
+			t.Errorf("request %d = %s, want start=%s length=%s cursor=%s", i, got.Encode(), want.start, want.length, want.cursor)
+		}
+	}
+
+	queries, _, _, err = readQueryLog(context.Background(), api, url.Values{}, nil, 100, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != logSize {
+		t.Errorf("expected the whole log of %d queries, got %d", logSize, len(queries))
+	}
+}
+
+func TestReadQueryLogMatchesBlockedQueries(t *testing.T) {
+	statuses := []string{"FORWARDED", "GRAVITY", "CACHE", "REGEX", "EXTERNAL_BLOCKED_NULL", "CACHE", "DENYLIST"}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+
+		page := queryLogResponse{RecordsFiltered: int64(len(statuses))}
+		for id := start; id < start+length && id < len(statuses); id++ {
+			page.Queries = append(page.Queries, queryLogEntry{ID: int64(id), Status: statuses[id]})
+		}
+
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	api := &apiClient{baseURL: ts.URL, http: ts.Client()}
+	blocked := func(q queryLogEntry) bool { return queryStatuses[q.Status] }
+
+	queries, total, complete, err := readQueryLog(context.Background(), api, url.Values{}, blocked, 3, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 3 || queries[0].ID != 1 || queries[1].ID != 3 || queries[2].ID != 4 || total != 3 || !complete {
+		t.Fatalf("unexpected blocked queries %+v, total %d", queries, total)
+	}
+
+	queries, total, complete, err = readQueryLog(context.Background(), api, url.Values{}, blocked, 100, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 4 || total != 4 || !complete {
+		t.Fatalf("expected all 4 blocked queries, got %+v, total %d", queries, total)
+	}
+
+	queries, total, complete, err = readQueryLog(context.Background(), api, url.Values{}, blocked, 100, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 1 || total != 1 || complete {
+		t.Fatalf("expected the search to stop after 3 queries with 1 blocked query, got %+v, total %d, complete %t", queries, total, complete)
+	}
+}
+
+func TestQueryLogDataReadsTimeWindowFromDisk(t *testing.T) {
+	var requests []url.Values
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query())
+
+		if err := json.NewEncoder(w).Encode(queryLogResponse{}); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	client := &Client{api: &apiClient{baseURL: ts.URL, http: ts.Client()}}
+
+	d := schema.TestResourceDataRaw(t, dataSourceQueryLog().Schema, map[string]interface{}{
+		"from":  "2024-01-01T00:00:00Z",
+		"until": "2024-01-02T00:00:00Z",
+	})
+
+	if diags := dataSourceQueryLogRead(context.Background(), d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if len(requests) != 1 || requests[0].Get("disk") != "true" || requests[0].Get("from") != "1704067200" {
+		t.Fatalf("expected the time window to be read from disk, got %v", requests)
+	}
+}
+
+func TestFormatQueryTime(t *testing.T) {
+	if got, want := formatQueryTime(1700000000.25), "2023-11-14T22:13:20.25Z"; got != want {
+		t.Errorf("formatQueryTime() = %q, want %q", got, want)
+	}
+}
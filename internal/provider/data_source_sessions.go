@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiSession is an API session reported by /api/auth/sessions
+type apiSession struct {
+	ID             int64  `json:"id"`
+	CurrentSession bool   `json:"current_session"`
+	Valid          bool   `json:"valid"`
+	App            bool   `json:"app"`
+	CLI            bool   `json:"cli"`
+	LoginAt        int64  `json:"login_at"`
+	LastActive     int64  `json:"last_active"`
+	ValidUntil     int64  `json:"valid_until"`
+	RemoteAddr     string `json:"remote_addr"`
+	UserAgent      string `json:"user_agent"`
+	TLS            struct {
+		Login bool `json:"login"`
+	} `json:"tls"`
+}
+
+// listSessions returns the API sessions of Pi-hole
+func listSessions(ctx context.Context, api *apiClient) ([]apiSession, error) {
+	var res struct {
+		Sessions []apiSession `json:"sessions"`
+	}
+
+	if err := api.do(ctx, http.MethodGet, "/api/auth/sessions", nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Sessions, nil
+}
+
+// dataSourceSessions returns a schema resource for listing the active Pi-hole API sessions
+func dataSourceSessions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the active Pi-hole API sessions. Pi-hole does not report whether a session was opened with a TOTP code, so sessions carry no TOTP flag.",
+		ReadContext: dataSourceSessionsRead,
+		Schema: map[string]*schema.Schema{
+			"sessions": {
+				Description: "Active API sessions",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the session",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"current_session": {
+							Description: "Whether this is the session of the provider",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"valid": {
+							Description: "Whether the session is still valid",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"remote_address": {
+							Description: "Address of the client that opened the session",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"user_agent": {
+							Description: "User agent of the client that opened the session",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"login_at": {
+							Description: "RFC 3339 time the session was opened",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_active": {
+							Description: "RFC 3339 time the session was last used",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"valid_until": {
+							Description: "RFC 3339 time the session expires unless used",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"app": {
+							Description: "Whether the session was opened with the application password",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"cli": {
+							Description: "Whether the session was opened with the CLI password",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"tls": {
+							Description: "Whether the session was opened over TLS",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceSessionsRead lists the API sessions
+func dataSourceSessionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	sessions, err := listSessions(ctx, client.api)
+	if err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	list := make([]map[string]interface{}, len(sessions))
+	hash := sha256.New()
+
+	for i, session := range sessions {
+		hash.Write([]byte(strconv.FormatInt(session.ID, 10)))
+		hash.Write([]byte{0})
+		hash.Write([]byte(strconv.FormatInt(session.LoginAt, 10)))
+		hash.Write([]byte{0})
+
+		list[i] = map[string]interface{}{
+			"id":              session.ID,
+			"current_session": session.CurrentSession,
+			"valid":           session.Valid,
+			"remote_address":  session.RemoteAddr,
+			"user_agent":      session.UserAgent,
+			"login_at":        formatUnixTime(session.LoginAt),
+			"last_active":     formatUnixTime(session.LastActive),
+			"valid_until":     formatUnixTime(session.ValidUntil),
+			"app":             session.App,
+			"cli":             session.CLI,
+			"tls":             session.TLS.Login,
+		}
+	}
+
+	if err := d.Set("sessions", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
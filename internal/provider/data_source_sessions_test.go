@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSessionsData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_sessions" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.pihole_sessions.all", "sessions.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_sessions.all", "sessions.*", map[string]string{"current_session": "true"}),
+				),
+			},
+		},
+	})
+}
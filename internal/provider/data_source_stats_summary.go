@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// statsSummaryResponse is the response of /api/stats/summary
+type statsSummaryResponse struct {
+	Queries struct {
+		Total          int64   `json:"total"`
+		Blocked        int64   `json:"blocked"`
+		PercentBlocked float64 `json:"percent_blocked"`
+		UniqueDomains  int64   `json:"unique_domains"`
+		Forwarded      int64   `json:"forwarded"`
+		Cached         int64   `json:"cached"`
+	} `json:"queries"`
+	Clients struct {
+		Active int64 `json:"active"`
+		Total  int64 `json:"total"`
+	} `json:"clients"`
+	Gravity struct {
+		DomainsBeingBlocked int64 `json:"domains_being_blocked"`
+		LastUpdate          int64 `json:"last_update"`
+	} `json:"gravity"`
+}
+
+// dataSourceStatsSummary returns a schema resource for reading Pi-hole query and blocking statistics
+func dataSourceStatsSummary() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the Pi-hole query and blocking statistics of the last 24 hours",
+		ReadContext: dataSourceStatsSummaryRead,
+		Schema: map[string]*schema.Schema{
+			"total_queries": {
+				Description: "Number of queries",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"blocked_queries": {
+				Description: "Number of blocked queries",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"forwarded_queries": {
+				Description: "Number of queries forwarded to an upstream server",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"cached_queries": {
+				Description: "Number of queries answered from the cache",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"percent_blocked": {
+				Description: "Percentage of blocked queries",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"unique_domains": {
+				Description: "Number of distinct domains queried",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"active_clients": {
+				Description: "Number of clients seen in the last 24 hours",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"total_clients": {
+				Description: "Number of clients ever seen",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"gravity_domains": {
+				Description: "Number of domains on the gravity blocklist",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"gravity_last_update": {
+				Description: "RFC 3339 time of the last gravity update, empty when gravity has never been updated",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceStatsSummaryRead reads the Pi-hole statistics summary
+func dataSourceStatsSummaryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var summary statsSummaryResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/stats/summary", nil, &summary); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	values := map[string]interface{}{
+		"total_queries":       summary.Queries.Total,
+		"blocked_queries":     summary.Queries.Blocked,
+		"forwarded_queries":   summary.Queries.Forwarded,
+		"cached_queries":      summary.Queries.Cached,
+		"percent_blocked":     summary.Queries.PercentBlocked,
+		"unique_domains":      summary.Queries.UniqueDomains,
+		"active_clients":      summary.Clients.Active,
+		"total_clients":       summary.Clients.Total,
+		"gravity_domains":     summary.Gravity.DomainsBeingBlocked,
+		"gravity_last_update": formatUnixTime(summary.Gravity.LastUpdate),
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(client.api.baseURL)
+
+	return diags
+}
+
+// formatUnixTime formats a Unix timestamp reported by Pi-hole as RFC 3339 in UTC, empty when unset
+func formatUnixTime(seconds int64) string {
+	if seconds <= 0 {
+		return ""
+	}
+
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccStatsSummaryData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_stats_summary" "stats" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_stats_summary.stats", "total_queries"),
+					resource.TestCheckResourceAttrSet("data.pihole_stats_summary.stats", "percent_blocked"),
+					resource.TestMatchResourceAttr("data.pihole_stats_summary.stats", "gravity_domains", regexp.MustCompile(`^\d+$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestFormatUnixTime(t *testing.T) {
+	if got := formatUnixTime(0); got != "" {
+		t.Errorf("formatUnixTime(0) = %q, want empty", got)
+	}
+
+	if got, want := formatUnixTime(1700000000), "2023-11-14T22:13:20Z"; got != want {
+		t.Errorf("formatUnixTime(1700000000) = %q, want %q", got, want)
+	}
+}
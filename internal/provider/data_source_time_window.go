@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// timeWindowSchemas are the optional time window arguments of the statistics data sources
+var timeWindowSchemas = map[string]*schema.Schema{
+	"from": {
+		Description:      "RFC 3339 start of the time window, e.g. `2024-01-01T00:00:00Z`.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+	},
+	"until": {
+		Description:      "RFC 3339 end of the time window, defaults to the time of the read when `from` is set.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+		RequiredWith:     []string{"from"},
+	},
+}
+
+// withTimeWindow adds the from and until arguments to a data source schema
+func withTimeWindow(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for attribute, windowSchema := range timeWindowSchemas {
+		windowSchema := *windowSchema
+		s[attribute] = &windowSchema
+	}
+
+	return s
+}
+
+// timeWindow is the time window configured by the from and until arguments of a data source
+type timeWindow struct {
+	from  time.Time
+	until time.Time
+}
+
+// newTimeWindow returns the time window configured on a data source, nil when from is not set
+func newTimeWindow(d *schema.ResourceData, now time.Time) (*timeWindow, error) {
+	from := d.Get("from").(string)
+	if from == "" {
+		return nil, nil
+	}
+
+	window := &timeWindow{until: now}
+
+	var err error
+	if window.from, err = time.Parse(time.RFC3339, from); err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+
+	if until := d.Get("until").(string); until != "" {
+		if window.until, err = time.Parse(time.RFC3339, until); err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+	}
+
+	if !window.from.Before(window.until) {
+		return nil, fmt.Errorf("from (%s) must be before until (%s)", window.from.Format(time.RFC3339), window.until.Format(time.RFC3339))
+	}
+
+	return window, nil
+}
+
+// encode adds the window as the from and until Unix timestamp query parameters
+func (w *timeWindow) encode(query url.Values) {
+	query.Set("from", strconv.FormatInt(w.from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(w.until.Unix(), 10))
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNewTimeWindow(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		raw       map[string]interface{}
+		wantNil   bool
+		wantErr   bool
+		wantQuery string
+	}{
+		{
+			name:    "unset",
+			raw:     map[string]interface{}{},
+			wantNil: true,
+		},
+		{
+			name:      "from only",
+			raw:       map[string]interface{}{"from": "2024-01-01T00:00:00Z"},
+			wantQuery: "from=1704067200&until=1704153600",
+		},
+		{
+			name:      "from and until",
+			raw:       map[string]interface{}{"from": "2024-01-01T00:00:00Z", "until": "2024-01-01T12:00:00+01:00"},
+			wantQuery: "from=1704067200&until=1704106800",
+		},
+		{
+			name:    "until before from",
+			raw:     map[string]interface{}{"from": "2024-01-01T00:00:00Z", "until": "2023-12-31T00:00:00Z"},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, withTimeWindow(map[string]*schema.Schema{}), c.raw)
+
+			window, err := newTimeWindow(d, now)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if c.wantNil {
+				if window != nil {
+					t.Fatalf("expected no window, got %+v", window)
+				}
+
+				return
+			}
+
+			query := url.Values{}
+			window.encode(query)
+
+			if got := query.Encode(); got != c.wantQuery {
+				t.Errorf("query = %q, want %q", got, c.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// topClientsResponse is the response of /api/stats/top_clients and /api/stats/database/top_clients
+type topClientsResponse struct {
+	Clients []struct {
+		IP    string `json:"ip"`
+		Name  string `json:"name"`
+		Count int64  `json:"count"`
+	} `json:"clients"`
+	TotalQueries   int64 `json:"total_queries"`
+	BlockedQueries int64 `json:"blocked_queries"`
+}
+
+// dataSourceTopClients returns a schema resource for reading the most active clients
+func dataSourceTopClients() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the clients with the most permitted or blocked queries, over the last 24 hours or a time window of the long-term database",
+		ReadContext: dataSourceTopClientsRead,
+		Schema: withTimeWindow(map[string]*schema.Schema{
+			"limit": {
+				Description:      "Maximum number of clients to return. Defaults to `10`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"blocked": {
+				Description: "Rank clients by blocked queries instead of all queries. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"total_queries": {
+				Description: "Number of queries within the time window",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"blocked_queries": {
+				Description: "Number of blocked queries within the time window",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"clients": {
+				Description: "Clients ordered by descending query count",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Description: "IP address of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Hostname of the client, empty when unknown",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"count": {
+							Description: "Number of queries of the client",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+// dataSourceTopClientsRead reads the top clients, from the long-term database when a time window is set
+func dataSourceTopClientsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	window, err := newTimeWindow(d, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	query := url.Values{}
+	query.Set("count", strconv.Itoa(d.Get("limit").(int)))
+	query.Set("blocked", strconv.FormatBool(d.Get("blocked").(bool)))
+
+	path := "/api/stats/top_clients"
+	if window != nil {
+		path = "/api/stats/database/top_clients"
+		window.encode(query)
+	}
+
+	var top topClientsResponse
+	if err := client.api.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &top); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	list := make([]map[string]interface{}, len(top.Clients))
+	for i, c := range top.Clients {
+		list[i] = map[string]interface{}{
+			"ip":    c.IP,
+			"name":  c.Name,
+			"count": c.Count,
+		}
+	}
+
+	if err := d.Set("clients", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("total_queries", top.TotalQueries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("blocked_queries", top.BlockedQueries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path + "?" + query.Encode())
+
+	return diags
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTopClientsData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_top_clients" "permitted" {
+					  limit = 5
+					}
+
+					data "pihole_top_clients" "blocked" {
+					  limit   = 5
+					  blocked = true
+					  from    = "2024-01-01T00:00:00Z"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.pihole_top_clients.permitted", "clients.#", regexp.MustCompile(`^[0-5]$`)),
+					resource.TestMatchResourceAttr("data.pihole_top_clients.blocked", "clients.#", regexp.MustCompile(`^[0-5]$`)),
+					resource.TestCheckResourceAttrSet("data.pihole_top_clients.blocked", "total_queries"),
+				),
+			},
+			{
+				Config: `
+					data "pihole_top_clients" "invalid" {
+					  until = "2024-01-01T00:00:00Z"
+					}
+				`,
+				ExpectError: regexp.MustCompile("all of `from,until` must be specified"),
+			},
+		},
+	})
+}
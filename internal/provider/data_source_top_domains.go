@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// topDomainsResponse is the response of /api/stats/top_domains and /api/stats/database/top_domains
+type topDomainsResponse struct {
+	Domains []struct {
+		Domain string `json:"domain"`
+		Count  int64  `json:"count"`
+	} `json:"domains"`
+	TotalQueries   int64 `json:"total_queries"`
+	BlockedQueries int64 `json:"blocked_queries"`
+}
+
+// dataSourceTopDomains returns a schema resource for reading the most queried domains
+func dataSourceTopDomains() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the most queried permitted or blocked domains, over the last 24 hours or a time window of the long-term database",
+		ReadContext: dataSourceTopDomainsRead,
+		Schema: withTimeWindow(map[string]*schema.Schema{
+			"limit": {
+				Description:      "Maximum number of domains to return. Defaults to `10`.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"blocked": {
+				Description: "Return the top blocked domains instead of the top permitted domains. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"total_queries": {
+				Description: "Number of queries within the time window",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"blocked_queries": {
+				Description: "Number of blocked queries within the time window",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"domains": {
+				Description: "Domains ordered by descending query count",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Description: "Queried domain",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"count": {
+							Description: "Number of queries for the domain",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+// dataSourceTopDomainsRead reads the top domains, from the long-term database when a time window is set
+func dataSourceTopDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	window, err := newTimeWindow(d, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	query := url.Values{}
+	query.Set("count", strconv.Itoa(d.Get("limit").(int)))
+	query.Set("blocked", strconv.FormatBool(d.Get("blocked").(bool)))
+
+	path := "/api/stats/top_domains"
+	if window != nil {
+		path = "/api/stats/database/top_domains"
+		window.encode(query)
+	}
+
+	var top topDomainsResponse
+	if err := client.api.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &top); err != nil {
+		return diagFromErr(err, nil)
+	}
+
+	list := make([]map[string]interface{}, len(top.Domains))
+	for i, domain := range top.Domains {
+		list[i] = map[string]interface{}{
+			"domain": domain.Domain,
+			"count":  domain.Count,
+		}
+	}
+
+	if err := d.Set("domains", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("total_queries", top.TotalQueries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("blocked_queries", top.BlockedQueries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path + "?" + query.Encode())
+
+	return diags
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Pi-hole API error keys with specific guidance
+const (
+	apiErrorKeyUnauthorized  = "unauthorized"
+	apiErrorKeyBadRequest    = "bad_request"
+	apiErrorKeyRateLimiting  = "rate_limiting"
+	apiErrorKeySeatsExceeded = "api_seats_exceeded"
+)
+
+// errorTokens returns the lowercased domain and address like tokens of an error message,
+// so attribute values are matched as a whole and e.g. 10.0.0.1 is not found in a message about 10.0.0.10
+func errorTokens(message string) map[string]bool {
+	fields := strings.FieldsFunc(message, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(".-_:%/", r)
+	})
+
+	tokens := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		tokens[strings.ToLower(strings.TrimRight(field, ".:"))] = true
+	}
+
+	return tokens
+}
+
+// diagFromErr converts an error into diagnostics, adding guidance for Pi-hole API errors.
+// When d is set, the diagnostic points at the first of the passed attributes whose whole value is mentioned by the error,
+// e.g. the ip attribute when Pi-hole rejects an invalid address.
+func diagFromErr(err error, d *schema.ResourceData, attributes ...string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, errTOTPRequired) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Pi-hole requires two-factor authentication",
+				Detail:        fmt.Sprintf("%s.\n\nSet totp_secret (or PIHOLE_TOTP_SECRET) to the base32 TOTP secret of the admin account, or authenticate with an app_password instead.", err),
+				AttributePath: cty.GetAttrPath("totp_secret"),
+			},
+		}
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+	}
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		diagnostic.Summary = "Pi-hole API error"
+		diagnostic.Detail = err.Error()
+	} else {
+		apiErr = &apiError{Message: err.Error()}
+	}
+
+	switch {
+	case apiErr.Key == apiErrorKeySeatsExceeded:
+		diagnostic.Summary = "Pi-hole has no free API sessions"
+		diagnostic.Detail = fmt.Sprintf("%s.\n\nPi-hole limits the number of concurrent API sessions (webserver.api.max_sessions). "+
+			"Enable session_cache so provider runs reuse a single session, remove stale sessions in the Pi-hole web interface, "+
+			"or increase the session limit.", err)
+	case apiErr.Key == apiErrorKeyRateLimiting || apiErr.StatusCode == http.StatusTooManyRequests:
+		diagnostic.Summary = "Pi-hole is rate limiting requests"
+		diagnostic.Detail = fmt.Sprintf("%s.\n\nLower max_concurrent_requests or requests_per_second in the provider configuration, "+
+			"or run Terraform with a lower -parallelism.", err)
+	case apiErr.Key == apiErrorKeyUnauthorized || apiErr.StatusCode == http.StatusUnauthorized:
+		diagnostic.Summary = "Pi-hole rejected the provider credentials"
+		diagnostic.Detail = fmt.Sprintf("%s.\n\nCheck the password, app_password or api_token configured for the provider. "+
+			"If two-factor authentication is enabled, configure totp_secret or use an app_password.", err)
+	case apiErr.Key == apiErrorKeyBadRequest || apiErr.StatusCode == http.StatusBadRequest:
+		diagnostic.Summary = "Pi-hole rejected the request"
+		if apiErr.Message != "" {
+			diagnostic.Summary = fmt.Sprintf("Pi-hole rejected the request: %s", apiErr.Message)
+		}
+	}
+
+	if d != nil {
+		mentioned := errorTokens(apiErr.Message + " " + apiErr.Hint)
+
+		for _, attribute := range attributes {
+			value, ok := d.Get(attribute).(string)
+			if ok && value != "" && mentioned[strings.ToLower(value)] {
+				diagnostic.AttributePath = cty.GetAttrPath(attribute)
+				break
+			}
+		}
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDiagFromErrAttributePath(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDNSRecord().Schema, map[string]interface{}{
+		"domain": "foo.com",
+		"ip":     "192.168.1.300",
+	})
+
+	err := newAPIError(http.MethodPatch, "/api/config", http.StatusBadRequest,
+		[]byte(`{"error":{"key":"bad_request","message":"Invalid value","hint":"dns.hosts[0]: neither a valid IPv4 nor IPv6 address (\"192.168.1.300\")"}}`))
+
+	diags := diagFromErr(fmt.Errorf("failed to create record: %w", err), d, "ip", "domain")
+	if len(diags) != 1 {
+		t.Fatalf("expected a single diagnostic, got %d", len(diags))
+	}
+
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("ip")) {
+		t.Errorf("expected diagnostic to point at ip, got %#v", diags[0].AttributePath)
+	}
+
+	if diags[0].Summary != "Pi-hole rejected the request: Invalid value" {
+		t.Errorf("unexpected summary %q", diags[0].Summary)
+	}
+}
+
+func TestDiagFromErrGuidance(t *testing.T) {
+	cases := map[string]struct {
+		err     error
+		summary string
+	}{
+		"seats exceeded": {
+			err:     newAPIError(http.MethodPost, "/api/auth", http.StatusTooManyRequests, []byte(`{"error":{"key":"api_seats_exceeded","message":"API seats exceeded"}}`)),
+			summary: "Pi-hole has no free API sessions",
+		},
+		"rate limited": {
+			err:     newAPIError(http.MethodGet, "/api/config/dns/hosts", http.StatusTooManyRequests, []byte(`Too many requests`)),
+			summary: "Pi-hole is rate limiting requests",
+		},
+		"unauthorized": {
+			err:     newAPIError(http.MethodGet, "/api/config/dns/hosts", http.StatusUnauthorized, []byte(`{"error":{"key":"unauthorized","message":"Unauthorized"}}`)),
+			summary: "Pi-hole rejected the provider credentials",
+		},
+		"totp required": {
+			err:     fmt.Errorf("failed to login: %w", errTOTPRequired),
+			summary: "Pi-hole requires two-factor authentication",
+		},
+	}
+
+	for name, c := range cases {
+		diags := diagFromErr(c.err, nil)
+		if len(diags) != 1 || diags[0].Summary != c.summary {
+			t.Errorf("%s: expected summary %q, got %+v", name, c.summary, diags)
+		}
+	}
+}
+
+func TestDiagFromErrMatchesWholeValues(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDNSRecord().Schema, map[string]interface{}{
+		"domain": "foo.com",
+		"ip":     "10.0.0.1",
+	})
+
+	err := newAPIError(http.MethodPatch, "/api/config", http.StatusBadRequest,
+		[]byte(`{"error":{"key":"bad_request","message":"Invalid value","hint":"dns.hosts[1]: duplicate entry \"10.0.0.10 www.foo.com\""}}`))
+
+	if diags := diagFromErr(err, d, "ip", "domain"); diags[0].AttributePath != nil {
+		t.Errorf("expected no attribute to be matched by a partial value, got %#v", diags[0].AttributePath)
+	}
+
+	err = newAPIError(http.MethodPatch, "/api/config", http.StatusBadRequest,
+		[]byte(`{"error":{"key":"bad_request","message":"Invalid value","hint":"dns.hosts[1]: duplicate entry \"10.0.0.10 FOO.com.\""}}`))
+
+	if diags := diagFromErr(err, d, "ip", "domain"); !diags[0].AttributePath.Equals(cty.GetAttrPath("domain")) {
+		t.Errorf("expected diagnostic to point at domain, got %#v", diags[0].AttributePath)
+	}
+}
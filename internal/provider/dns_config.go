@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+// hostRecordPrefix starts the dnsmasq lines holding local DNS records stored as host records
+const hostRecordPrefix = "host-record="
+
+// hostRecord is a misc.dnsmasq_lines entry of the form "host-record=<domain>[,<domain>...],<ip>[,<ip>...][,<ttl>] [# <comment>]".
+// Local DNS records opting into host_record are stored as host records, as dns.hosts entries cannot carry a TTL.
+// dnsmasq ignores the rest of a configuration line from a # following whitespace, which holds the comment.
+type hostRecord struct {
+	Domains []string
+	IPs     []string
+	TTL     int
+	Comment string
+}
+
+// splitComment splits an entry of the form "<value> # <comment>" into its value and comment
+func splitComment(entry string) (value string, comment string) {
+	value, comment, _ = strings.Cut(entry, "#")
+
+	return strings.TrimSpace(value), strings.TrimSpace(comment)
+}
+
+// appendComment returns the entry followed by its comment, if any
+func appendComment(entry string, comment string) string {
+	if comment == "" {
+		return entry
+	}
+
+	return entry + " # " + comment
+}
+
+// parseHostsEntry parses a dns.hosts entry of the form "<ip> <domain> [<domain>...] [# <comment>]".
+// Pi-hole writes dns.hosts to a hosts file, in which everything from a # to the end of the line is a comment.
+func parseHostsEntry(entry string) (ip string, domains []string, comment string, ok bool) {
+	value, comment := splitComment(entry)
+
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return "", nil, "", false
+	}
+
+	return fields[0], fields[1:], comment, true
+}
+
+// formatHostsEntry returns the dns.hosts entry for a local DNS record
+func formatHostsEntry(record pihole.DNSRecord) string {
+	return appendComment(fmt.Sprintf("%s %s", record.IP, record.Domain), record.Comment)
+}
+
+// parseHostRecord parses a misc.dnsmasq_lines entry, ok is false for lines other than host records
+func parseHostRecord(line string) (record hostRecord, ok bool) {
+	value, comment := splitDNSMasqComment(line)
+
+	value, ok = strings.CutPrefix(value, hostRecordPrefix)
+	if !ok {
+		return hostRecord{}, false
+	}
+
+	fields := strings.Split(value, ",")
+	if ttl, err := strconv.Atoi(strings.TrimSpace(fields[len(fields)-1])); err == nil {
+		record.TTL = ttl
+		fields = fields[:len(fields)-1]
+	}
+
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+
+		switch {
+		case field == "":
+			continue
+		case net.ParseIP(field) != nil:
+			record.IPs = append(record.IPs, field)
+		default:
+			record.Domains = append(record.Domains, field)
+		}
+	}
+
+	record.Comment = comment
+
+	return record, len(record.Domains) > 0 && len(record.IPs) > 0
+}
+
+// formatHostRecord returns the misc.dnsmasq_lines entry of a host record
+func formatHostRecord(record hostRecord) string {
+	fields := append(slices.Clone(record.Domains), record.IPs...)
+	if record.TTL > 0 {
+		fields = append(fields, strconv.Itoa(record.TTL))
+	}
+
+	return appendComment(hostRecordPrefix+strings.Join(fields, ","), record.Comment)
+}
+
+// parseCNAMEEntry parses a dns.cnameRecords entry of the form "<domain>,<target>[,<ttl>]".
+// Pi-hole turns these entries into dnsmasq cname options, which have no comment field.
+func parseCNAMEEntry(entry string) (pihole.CNAMERecord, bool) {
+	parts := strings.Split(entry, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return pihole.CNAMERecord{}, false
+	}
+
+	record := pihole.CNAMERecord{
+		Domain: strings.TrimSpace(parts[0]),
+		Target: strings.TrimSpace(parts[1]),
+	}
+
+	if len(parts) == 3 {
+		ttl, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil {
+			return pihole.CNAMERecord{}, false
+		}
+
+		record.TTL = ttl
+		record.HasTTL = true
+	}
+
+	return record, true
+}
+
+// formatCNAMEEntry returns the dns.cnameRecords entry for a CNAME record
+func formatCNAMEEntry(record pihole.CNAMERecord) string {
+	if record.HasTTL {
+		return fmt.Sprintf("%s,%s,%d", record.Domain, record.Target, record.TTL)
+	}
+
+	return fmt.Sprintf("%s,%s", record.Domain, record.Target)
+}
+
+// dnsConfig holds the raw local DNS and CNAME arrays of the Pi-hole configuration
+type dnsConfig struct {
+	Hosts        []string `json:"hosts"`
+	CNAMERecords []string `json:"cnameRecords"`
+
+	// DNSMasqLines holds misc.dnsmasq_lines, of which only host records are managed
+	DNSMasqLines []string `json:"dnsmasq_lines"`
+}
+
+// getDNSConfig reads the dns.hosts, dns.cnameRecords and misc.dnsmasq_lines configuration arrays
+func (c *apiClient) getDNSConfig(ctx context.Context) (*dnsConfig, error) {
+	var hosts, cnames struct {
+		Config struct {
+			DNS dnsConfig `json:"dns"`
+		} `json:"config"`
+	}
+
+	var lines struct {
+		Config struct {
+			Misc dnsConfig `json:"misc"`
+		} `json:"config"`
+	}
+
+	if err := c.getConfig(ctx, "dns.hosts", &hosts); err != nil {
+		return nil, err
+	}
+
+	if err := c.getConfig(ctx, "dns.cnameRecords", &cnames); err != nil {
+		return nil, err
+	}
+
+	if err := c.getConfig(ctx, "misc.dnsmasq_lines", &lines); err != nil {
+		return nil, err
+	}
+
+	return &dnsConfig{
+		Hosts:        hosts.Config.DNS.Hosts,
+		CNAMERecords: cnames.Config.DNS.CNAMERecords,
+		DNSMasqLines: lines.Config.Misc.DNSMasqLines,
+	}, nil
+}
+
+// addDNSRecord appends a local DNS record, adding the same record twice is a no-op.
+// With asHostRecord, the record is added as a host record to misc.dnsmasq_lines, otherwise to dns.hosts without its TTL.
+func (c *dnsConfig) addDNSRecord(record pihole.DNSRecord, asHostRecord bool) error {
+	for _, existing := range c.dnsRecords() {
+		if !sameDomain(existing.Domain, record.Domain) {
+			continue
+		}
+
+		if existing.IP == record.IP {
+			return nil
+		}
+
+		return fmt.Errorf("local DNS record for %q already exists with IP %q", record.Domain, existing.IP)
+	}
+
+	if asHostRecord {
+		c.DNSMasqLines = append(c.DNSMasqLines, formatHostRecord(hostRecord{
+			Domains: []string{record.Domain},
+			IPs:     []string{record.IP},
+			TTL:     record.TTL,
+			Comment: record.Comment,
+		}))
+
+		return nil
+	}
+
+	c.Hosts = append(c.Hosts, formatHostsEntry(record))
+
+	return nil
+}
+
+// removeDNSRecord removes every local DNS record of a domain from dns.hosts and misc.dnsmasq_lines,
+// leaving other domains of shared entries in place
+func (c *dnsConfig) removeDNSRecord(domain string) {
+	hosts := make([]string, 0, len(c.Hosts))
+
+	for _, entry := range c.Hosts {
+		ip, domains, comment, ok := parseHostsEntry(entry)
+		if !ok {
+			hosts = append(hosts, entry)
+			continue
+		}
+
+		remaining := make([]string, 0, len(domains))
+		for _, d := range domains {
+			if !sameDomain(d, domain) {
+				remaining = append(remaining, d)
+			}
+		}
+
+		switch {
+		case len(remaining) == len(domains):
+			hosts = append(hosts, entry)
+		case len(remaining) > 0:
+			hosts = append(hosts, appendComment(ip+" "+strings.Join(remaining, " "), comment))
+		}
+	}
+
+	c.Hosts = hosts
+
+	lines := make([]string, 0, len(c.DNSMasqLines))
+
+	for _, line := range c.DNSMasqLines {
+		record, ok := parseHostRecord(line)
+		if !ok {
+			lines = append(lines, line)
+			continue
+		}
+
+		remaining := make([]string, 0, len(record.Domains))
+		for _, d := range record.Domains {
+			if !sameDomain(d, domain) {
+				remaining = append(remaining, d)
+			}
+		}
+
+		switch {
+		case len(remaining) == len(record.Domains):
+			lines = append(lines, line)
+		case len(remaining) > 0:
+			record.Domains = remaining
+			lines = append(lines, formatHostRecord(record))
+		}
+	}
+
+	c.DNSMasqLines = lines
+}
+
+// addCNAMERecord appends a CNAME record, adding the same record twice is a no-op
+func (c *dnsConfig) addCNAMERecord(record pihole.CNAMERecord) error {
+	for _, entry := range c.CNAMERecords {
+		existing, ok := parseCNAMEEntry(entry)
+		if !ok || !sameDomain(existing.Domain, record.Domain) {
+			continue
+		}
+
+		if existing.Target == record.Target && existing.HasTTL == record.HasTTL && existing.TTL == record.TTL {
+			return nil
+		}
+
+		return fmt.Errorf("CNAME record for %q already exists with target %q", record.Domain, existing.Target)
+	}
+
+	c.CNAMERecords = append(c.CNAMERecords, formatCNAMEEntry(record))
+
+	return nil
+}
+
+// removeCNAMERecord removes the CNAME record of a domain
+func (c *dnsConfig) removeCNAMERecord(domain string) {
+	records := make([]string, 0, len(c.CNAMERecords))
+
+	for _, entry := range c.CNAMERecords {
+		if existing, ok := parseCNAMEEntry(entry); ok && sameDomain(existing.Domain, domain) {
+			continue
+		}
+
+		records = append(records, entry)
+	}
+
+	c.CNAMERecords = records
+}
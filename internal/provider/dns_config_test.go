@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+func TestParseCNAMEEntry(t *testing.T) {
+	cases := map[string]struct {
+		record pihole.CNAMERecord
+		ok     bool
+	}{
+		"foo.com,bar.com":       {pihole.CNAMERecord{Domain: "foo.com", Target: "bar.com"}, true},
+		"foo.com,bar.com,60":    {pihole.CNAMERecord{Domain: "foo.com", Target: "bar.com", TTL: 60, HasTTL: true}, true},
+		"foo.com":               {pihole.CNAMERecord{}, false},
+		"foo.com,bar.com,never": {pihole.CNAMERecord{}, false},
+	}
+
+	for entry, expected := range cases {
+		record, ok := parseCNAMEEntry(entry)
+		if ok != expected.ok || record != expected.record {
+			t.Errorf("%q: expected %+v (%t), got %+v (%t)", entry, expected.record, expected.ok, record, ok)
+		}
+
+		if ok && formatCNAMEEntry(record) != entry {
+			t.Errorf("%q: expected entry to round trip, got %q", entry, formatCNAMEEntry(record))
+		}
+	}
+}
+
+func TestDNSConfigRemoveDNSRecord(t *testing.T) {
+	config := dnsConfig{Hosts: []string{
+		"10.0.0.1 foo.com",
+		"10.0.0.2 bar.com foo.com baz.com # shared",
+		"10.0.0.3 qux.com",
+	}}
+
+	config.removeDNSRecord("foo.com")
+
+	expected := []string{"10.0.0.2 bar.com baz.com # shared", "10.0.0.3 qux.com"}
+	if !reflect.DeepEqual(config.Hosts, expected) {
+		t.Fatalf("expected %v, got %v", expected, config.Hosts)
+	}
+}
+
+func TestParseHostsEntry(t *testing.T) {
+	ip, domains, comment, ok := parseHostsEntry("10.0.0.1 foo.com bar.com # managed by terraform")
+	if !ok || ip != "10.0.0.1" || !reflect.DeepEqual(domains, []string{"foo.com", "bar.com"}) || comment != "managed by terraform" {
+		t.Fatalf("unexpected hosts entry: %q %v %q %t", ip, domains, comment, ok)
+	}
+
+	if _, _, _, ok := parseHostsEntry("10.0.0.1 # foo.com"); ok {
+		t.Fatal("expected commented out domain to be ignored")
+	}
+
+	record := pihole.DNSRecord{Domain: "foo.com", IP: "10.0.0.1", Comment: "NET-123"}
+	if entry := formatHostsEntry(record); entry != "10.0.0.1 foo.com # NET-123" {
+		t.Fatalf("unexpected hosts entry %q", entry)
+	}
+}
+
+func TestDNSConfigAddDNSRecord(t *testing.T) {
+	config := dnsConfig{Hosts: []string{"10.0.0.1 foo.com"}}
+
+	if err := config.addDNSRecord(pihole.DNSRecord{Domain: "foo.com", IP: "10.0.0.1"}, false); err != nil {
+		t.Fatalf("expected adding an existing record to be a no-op, got %s", err)
+	}
+
+	if err := config.addDNSRecord(pihole.DNSRecord{Domain: "foo.com", IP: "10.0.0.2"}, false); err == nil {
+		t.Fatal("expected conflicting record to fail")
+	}
+
+	if err := config.addDNSRecord(pihole.DNSRecord{Domain: "bar.com", IP: "10.0.0.2"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"10.0.0.1 foo.com", "10.0.0.2 bar.com"}
+	if !reflect.DeepEqual(config.Hosts, expected) {
+		t.Fatalf("expected %v, got %v", expected, config.Hosts)
+	}
+}
+
+func TestParseHostRecord(t *testing.T) {
+	cases := map[string]struct {
+		record hostRecord
+		ok     bool
+	}{
+		"host-record=foo.com,10.0.0.1,60":                       {hostRecord{Domains: []string{"foo.com"}, IPs: []string{"10.0.0.1"}, TTL: 60}, true},
+		"host-record=foo.com,bar.com,10.0.0.1,fd00::1 # shared": {hostRecord{Domains: []string{"foo.com", "bar.com"}, IPs: []string{"10.0.0.1", "fd00::1"}, Comment: "shared"}, true},
+		"host-record=foo.com":                                   {hostRecord{}, false},
+		"server=/foo.com/10.0.0.1":                              {hostRecord{}, false},
+	}
+
+	for line, expected := range cases {
+		record, ok := parseHostRecord(line)
+		if ok != expected.ok {
+			t.Errorf("%q: expected %t, got %t", line, expected.ok, ok)
+			continue
+		}
+
+		if ok && !reflect.DeepEqual(record, expected.record) {
+			t.Errorf("%q: expected %+v, got %+v", line, expected.record, record)
+		}
+
+		if ok && formatHostRecord(record) != line {
+			t.Errorf("%q: expected line to round trip, got %q", line, formatHostRecord(record))
+		}
+	}
+}
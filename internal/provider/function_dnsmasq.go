@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// functionParseDNSMasqReturnType is the object type returned by parse_dnsmasq
+var functionParseDNSMasqReturnType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"dns_records":   tftypes.List{ElementType: functionDNSRecordType},
+		"cname_records": tftypes.List{ElementType: functionCNAMERecordType},
+	},
+}
+
+// functionParseDNSMasq returns the parse_dnsmasq function, turning dnsmasq configuration into local DNS and CNAME records
+func functionParseDNSMasq() providerFunction {
+	return providerFunction{
+		definition: &tfprotov5.Function{
+			Summary: "Parse dnsmasq configuration into local DNS and CNAME records",
+			Description: "Returns an object with `dns_records` and `cname_records` lists, with the attributes of `pihole_dns_record` and `pihole_cname_record`. " +
+				"Reads `address=/<domain>/<ip>`, `host-record=` and `cname=` lines; blocking `address=` lines without an IP address and other options are ignored. " +
+				"Note that dnsmasq `address=` lines also answer for subdomains, whereas Pi-hole local DNS records only answer for the domain itself.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "content",
+					Description: "Content of a dnsmasq configuration file, e.g. `file(\"/etc/dnsmasq.d/02-lan.conf\")`",
+					Type:        tftypes.String,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: functionParseDNSMasqReturnType,
+			},
+		},
+		call: func(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+			var content string
+			if err := arguments[0].As(&content); err != nil {
+				return tftypes.Value{}, functionArgumentError(0, "failed to read content: %s", err)
+			}
+
+			dnsRecords, cnameRecords, err := parseDNSMasqFile(content)
+			if err != nil {
+				return tftypes.Value{}, functionArgumentError(0, "%s", err)
+			}
+
+			dnsValues := make([]tftypes.Value, len(dnsRecords))
+			for i, record := range dnsRecords {
+				dnsValues[i] = newFunctionDNSRecordValue(record.Domain, record.IP, record.TTL, record.Comment)
+			}
+
+			cnameValues := make([]tftypes.Value, len(cnameRecords))
+			for i, record := range cnameRecords {
+				cnameValues[i] = newFunctionCNAMERecordValue(record.Domain, record.Target, record.TTL)
+			}
+
+			return tftypes.NewValue(functionParseDNSMasqReturnType, map[string]tftypes.Value{
+				"dns_records":   tftypes.NewValue(tftypes.List{ElementType: functionDNSRecordType}, dnsValues),
+				"cname_records": tftypes.NewValue(tftypes.List{ElementType: functionCNAMERecordType}, cnameValues),
+			}), nil
+		},
+	}
+}
+
+// splitDNSMasqComment splits a dnsmasq line into its option and comment.
+// Unlike hosts entries, # only starts a comment at the beginning of a line or after whitespace, as in address=/domain/#.
+func splitDNSMasqComment(line string) (option string, comment string) {
+	for i, r := range line {
+		if r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		}
+	}
+
+	return strings.TrimSpace(line), ""
+}
+
+// parseDNSMasqFile parses the address, host-record and cname options of dnsmasq configuration into records
+func parseDNSMasqFile(content string) (dnsRecords []pihole.DNSRecord, cnameRecords []pihole.CNAMERecord, err error) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		option, comment := splitDNSMasqComment(scanner.Text())
+
+		key, value, _ := strings.Cut(option, "=")
+
+		var domains []string
+
+		switch strings.TrimSpace(key) {
+		case "address":
+			// address=/<domain>[/<domain>...]/<ip>
+			parts := strings.Split(strings.TrimSpace(value), "/")
+			if len(parts) < 3 || parts[0] != "" {
+				return nil, nil, fmt.Errorf("line %d: expected address=/<domain>/<ip>", line)
+			}
+
+			ip := parts[len(parts)-1]
+			if net.ParseIP(ip) == nil {
+				continue
+			}
+
+			for _, domain := range parts[1 : len(parts)-1] {
+				if domain != "" {
+					domains = append(domains, domain)
+					dnsRecords = append(dnsRecords, pihole.DNSRecord{Domain: domain, IP: ip, Comment: comment})
+				}
+			}
+		case "host-record":
+			record, ok := parseHostRecord(option)
+			if !ok {
+				return nil, nil, fmt.Errorf("line %d: expected host-record=<domain>,<ip>[,<ttl>]", line)
+			}
+
+			domains = record.Domains
+
+			for _, domain := range record.Domains {
+				for _, ip := range record.IPs {
+					dnsRecords = append(dnsRecords, pihole.DNSRecord{Domain: domain, IP: ip, TTL: record.TTL, Comment: comment})
+				}
+			}
+		case "cname":
+			// cname=<alias>[,<alias>...],<target>[,<ttl>]
+			fields := strings.Split(value, ",")
+
+			var ttl int
+			if n, err := strconv.Atoi(strings.TrimSpace(fields[len(fields)-1])); err == nil {
+				ttl = n
+				fields = fields[:len(fields)-1]
+			}
+
+			if len(fields) < 2 {
+				return nil, nil, fmt.Errorf("line %d: expected cname=<alias>,<target>[,<ttl>]", line)
+			}
+
+			target := normalizeDomain(fields[len(fields)-1])
+			domains = append(domains, target)
+
+			for _, alias := range fields[:len(fields)-1] {
+				domains = append(domains, alias)
+				cnameRecords = append(cnameRecords, pihole.CNAMERecord{Domain: alias, Target: target, TTL: ttl, HasTTL: ttl > 0})
+			}
+		default:
+			continue
+		}
+
+		for _, domain := range domains {
+			if err := checkDomain(normalizeDomain(domain)); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %q is not a valid domain: %w", line, domain, err)
+			}
+		}
+	}
+
+	for i := range dnsRecords {
+		dnsRecords[i].Domain = normalizeDomain(dnsRecords[i].Domain)
+	}
+
+	for i := range cnameRecords {
+		cnameRecords[i].Domain = normalizeDomain(cnameRecords[i].Domain)
+	}
+
+	return dnsRecords, cnameRecords, scanner.Err()
+}
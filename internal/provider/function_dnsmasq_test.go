@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestParseDNSMasqFile(t *testing.T) {
+	content := `# LAN records
+address=/nas.lan/10.0.0.1
+address=/ads.example.com/#
+address=/tracker.example.com/
+host-record=Router.lan,10.0.0.254,fd00::1,300 # core
+cname=www.lan,wiki.lan,nas.lan,60
+server=/corp.example.com/10.1.0.53
+`
+
+	dnsRecords, cnameRecords, err := parseDNSMasqFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dnsRecords) != 3 {
+		t.Fatalf("expected 3 local DNS records, got %+v", dnsRecords)
+	}
+
+	if dnsRecords[0].Domain != "nas.lan" || dnsRecords[0].IP != "10.0.0.1" {
+		t.Errorf("unexpected address record %+v", dnsRecords[0])
+	}
+
+	if dnsRecords[1].Domain != "router.lan" || dnsRecords[1].IP != "10.0.0.254" || dnsRecords[1].TTL != 300 || dnsRecords[1].Comment != "core" {
+		t.Errorf("unexpected host record %+v", dnsRecords[1])
+	}
+
+	if dnsRecords[2].IP != "fd00::1" {
+		t.Errorf("unexpected host record %+v", dnsRecords[2])
+	}
+
+	if len(cnameRecords) != 2 {
+		t.Fatalf("expected 2 CNAME records, got %+v", cnameRecords)
+	}
+
+	for i, alias := range []string{"www.lan", "wiki.lan"} {
+		if cnameRecords[i].Domain != alias || cnameRecords[i].Target != "nas.lan" || cnameRecords[i].TTL != 60 {
+			t.Errorf("unexpected CNAME record %+v", cnameRecords[i])
+		}
+	}
+
+	for _, invalid := range []string{"address=nas.lan/10.0.0.1", "cname=www.lan", "host-record=nas.lan", "address=/nas!lan/10.0.0.1"} {
+		if _, _, err := parseDNSMasqFile(invalid); err == nil {
+			t.Errorf("expected %q to fail", invalid)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// functionParseHosts returns the parse_hosts function, turning hosts file content into local DNS records
+func functionParseHosts() providerFunction {
+	return providerFunction{
+		definition: &tfprotov5.Function{
+			Summary: "Parse a hosts file into local DNS records",
+			Description: "Returns one object per domain of each hosts file entry, with the `domain`, `ip`, `ttl` and `comment` attributes of `pihole_dns_record`. " +
+				"Domains are normalized, inline comments are kept and comment lines are ignored.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "content",
+					Description: "Content of a hosts file, e.g. `file(\"/etc/hosts\")`",
+					Type:        tftypes.String,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.List{ElementType: functionDNSRecordType},
+			},
+		},
+		call: func(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+			var content string
+			if err := arguments[0].As(&content); err != nil {
+				return tftypes.Value{}, functionArgumentError(0, "failed to read content: %s", err)
+			}
+
+			records, err := parseHostsFile(content)
+			if err != nil {
+				return tftypes.Value{}, functionArgumentError(0, "%s", err)
+			}
+
+			values := make([]tftypes.Value, len(records))
+			for i, record := range records {
+				values[i] = newFunctionDNSRecordValue(record.Domain, record.IP, record.TTL, record.Comment)
+			}
+
+			return tftypes.NewValue(tftypes.List{ElementType: functionDNSRecordType}, values), nil
+		},
+	}
+}
+
+// functionRenderHosts returns the render_hosts function, turning local DNS records into hosts file content
+func functionRenderHosts() providerFunction {
+	return providerFunction{
+		definition: &tfprotov5.Function{
+			Summary: "Render local DNS records as a hosts file",
+			Description: "Returns a hosts file with one line per record. Accepts any list of objects with `domain` and `ip` attributes and an optional `comment`, " +
+				"such as the result of `parse_hosts` or the `records` of the `pihole_dns_records` data source.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "records",
+					Description: "List or set of objects with `domain`, `ip` and optionally `comment` attributes",
+					Type:        tftypes.DynamicPseudoType,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.String,
+			},
+		},
+		call: func(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+			records, err := functionDNSRecords(arguments[0])
+			if err != nil {
+				return tftypes.Value{}, functionArgumentError(0, "%s", err)
+			}
+
+			return tftypes.NewValue(tftypes.String, renderHostsFile(records)), nil
+		},
+	}
+}
+
+// parseHostsFile parses hosts file content into local DNS records, one per domain of each entry
+func parseHostsFile(content string) ([]pihole.DNSRecord, error) {
+	var records []pihole.DNSRecord
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		if value, _ := splitComment(scanner.Text()); value == "" {
+			continue
+		}
+
+		ip, domains, comment, ok := parseHostsEntry(scanner.Text())
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected an IP address followed by at least one domain", line)
+		}
+
+		if net.ParseIP(ip) == nil {
+			return nil, fmt.Errorf("line %d: %q is not a valid IP address", line, ip)
+		}
+
+		for _, domain := range domains {
+			domain = normalizeDomain(domain)
+			if err := checkDomain(domain); err != nil {
+				return nil, fmt.Errorf("line %d: %q is not a valid domain: %w", line, domain, err)
+			}
+
+			records = append(records, pihole.DNSRecord{Domain: domain, IP: ip, Comment: comment})
+		}
+	}
+
+	return records, scanner.Err()
+}
+
+// renderHostsFile renders local DNS records as hosts file content
+func renderHostsFile(records []pihole.DNSRecord) string {
+	var content strings.Builder
+
+	for _, record := range records {
+		content.WriteString(formatHostsEntry(record))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// functionDNSRecords decodes a list, set or tuple of objects with domain, ip and optional comment attributes
+func functionDNSRecords(value tftypes.Value) ([]pihole.DNSRecord, error) {
+	if !value.Type().Is(tftypes.List{}) && !value.Type().Is(tftypes.Set{}) && !value.Type().Is(tftypes.Tuple{}) {
+		return nil, fmt.Errorf("expected a list of records, got %s", value.Type())
+	}
+
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		return nil, err
+	}
+
+	records := make([]pihole.DNSRecord, 0, len(elements))
+
+	for i, element := range elements {
+		var attributes map[string]tftypes.Value
+		if err := element.As(&attributes); err != nil {
+			return nil, fmt.Errorf("record %d: expected an object, got %s", i, element.Type())
+		}
+
+		var record pihole.DNSRecord
+		var err error
+
+		if record.Domain, err = functionStringAttribute(attributes, "domain", true); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		if record.IP, err = functionStringAttribute(attributes, "ip", true); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		if record.Comment, err = functionStringAttribute(attributes, "comment", false); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		if net.ParseIP(record.IP) == nil {
+			return nil, fmt.Errorf("record %d: %q is not a valid IP address", i, record.IP)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// functionStringAttribute returns a string attribute of a function argument object, empty when optional and missing or null
+func functionStringAttribute(attributes map[string]tftypes.Value, name string, required bool) (string, error) {
+	value, ok := attributes[name]
+	if !ok || value.IsNull() {
+		if required {
+			return "", fmt.Errorf("missing %s attribute", name)
+		}
+
+		return "", nil
+	}
+
+	var s string
+	if err := value.As(&s); err != nil {
+		return "", fmt.Errorf("%s attribute must be a string", name)
+	}
+
+	return s, nil
+}
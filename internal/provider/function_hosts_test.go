@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	pihole "github.com/awaybreaktoday/lib-pihole-go"
+)
+
+func TestParseHostsFile(t *testing.T) {
+	content := `# Static hosts
+127.0.0.1	localhost
+10.0.0.1 NAS.lan. nas # storage
+
+fd00::1 router.lan
+`
+
+	records, err := parseHostsFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []pihole.DNSRecord{
+		{Domain: "localhost", IP: "127.0.0.1"},
+		{Domain: "nas.lan", IP: "10.0.0.1", Comment: "storage"},
+		{Domain: "nas", IP: "10.0.0.1", Comment: "storage"},
+		{Domain: "router.lan", IP: "fd00::1"},
+	}
+
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d records, got %+v", len(expected), records)
+	}
+
+	for i := range expected {
+		if records[i].Domain != expected[i].Domain || records[i].IP != expected[i].IP || records[i].Comment != expected[i].Comment {
+			t.Errorf("record %d: expected %+v, got %+v", i, expected[i], records[i])
+		}
+	}
+
+	for _, invalid := range []string{"10.0.0.1", "nas.lan 10.0.0.1", "10.0.0.1 -nas.lan"} {
+		if _, err := parseHostsFile(invalid); err == nil {
+			t.Errorf("expected %q to fail", invalid)
+		}
+	}
+}
+
+func TestRenderHostsFile(t *testing.T) {
+	content := renderHostsFile([]pihole.DNSRecord{
+		{Domain: "nas.lan", IP: "10.0.0.1", Comment: "storage"},
+		{Domain: "router.lan", IP: "fd00::1"},
+	})
+
+	if content != "10.0.0.1 nas.lan # storage\nfd00::1 router.lan\n" {
+		t.Fatalf("unexpected hosts file %q", content)
+	}
+
+	records, err := parseHostsFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(renderHostsFile(records), content) {
+		t.Fatal("expected hosts file to round trip")
+	}
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerFunction is a provider-defined function, callable as provider::pihole::<name> from Terraform 1.8
+type providerFunction struct {
+	definition *tfprotov5.Function
+
+	// call returns the result of the function, arguments have been checked against the definition by Terraform
+	call func(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError)
+}
+
+// providerFunctions returns the provider-defined functions by name
+func providerFunctions() map[string]providerFunction {
+	return map[string]providerFunction{
+		"parse_dnsmasq": functionParseDNSMasq(),
+		"parse_hosts":   functionParseHosts(),
+		"render_hosts":  functionRenderHosts(),
+	}
+}
+
+// providerServer serves the plugin SDK provider together with the provider-defined functions,
+// which the plugin SDK does not support
+type providerServer struct {
+	tfprotov5.ProviderServer
+
+	functions map[string]providerFunction
+}
+
+// ProviderServer returns the protocol server of the provider, including provider-defined functions
+func ProviderServer() tfprotov5.ProviderServer {
+	return &providerServer{
+		ProviderServer: Provider().GRPCProvider(),
+		functions:      providerFunctions(),
+	}
+}
+
+func (s *providerServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(s.functions))
+	for name := range s.functions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		resp.Functions = append(resp.Functions, tfprotov5.FunctionMetadata{Name: name})
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Functions == nil {
+		resp.Functions = make(map[string]*tfprotov5.Function, len(s.functions))
+	}
+
+	for name, function := range s.functions {
+		resp.Functions[name] = function.definition
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	resp := &tfprotov5.GetFunctionsResponse{
+		Functions: make(map[string]*tfprotov5.Function, len(s.functions)),
+	}
+
+	for name, function := range s.functions {
+		resp.Functions[name] = function.definition
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	function, ok := s.functions[req.Name]
+	if !ok {
+		return s.ProviderServer.CallFunction(ctx, req)
+	}
+
+	arguments := make([]tftypes.Value, len(req.Arguments))
+
+	for i, argument := range req.Arguments {
+		parameterType := tftypes.Type(tftypes.DynamicPseudoType)
+		if i < len(function.definition.Parameters) {
+			parameterType = function.definition.Parameters[i].Type
+		}
+
+		value, err := argument.Unmarshal(parameterType)
+		if err != nil {
+			return &tfprotov5.CallFunctionResponse{Error: functionArgumentError(i, "failed to decode argument: %s", err)}, nil
+		}
+
+		arguments[i] = value
+	}
+
+	result, functionErr := function.call(arguments)
+	if functionErr != nil {
+		return &tfprotov5.CallFunctionResponse{Error: functionErr}, nil
+	}
+
+	value, err := tfprotov5.NewDynamicValue(function.definition.Return.Type, result)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{Error: &tfprotov5.FunctionError{Text: fmt.Sprintf("failed to encode result: %s", err)}}, nil
+	}
+
+	return &tfprotov5.CallFunctionResponse{Result: &value}, nil
+}
+
+// functionArgumentError returns a function error pointing at an argument
+func functionArgumentError(argument int, format string, a ...interface{}) *tfprotov5.FunctionError {
+	index := int64(argument)
+
+	return &tfprotov5.FunctionError{
+		Text:             fmt.Sprintf(format, a...),
+		FunctionArgument: &index,
+	}
+}
+
+// functionDNSRecordType is the object type of local DNS records returned by functions,
+// matching the arguments of pihole_dns_record
+var functionDNSRecordType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"domain":  tftypes.String,
+		"ip":      tftypes.String,
+		"ttl":     tftypes.Number,
+		"comment": tftypes.String,
+	},
+}
+
+// functionCNAMERecordType is the object type of CNAME records returned by functions,
+// matching the arguments of pihole_cname_record
+var functionCNAMERecordType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"domain": tftypes.String,
+		"target": tftypes.String,
+		"ttl":    tftypes.Number,
+	},
+}
+
+// newFunctionDNSRecordValue returns the function value of a local DNS record
+func newFunctionDNSRecordValue(domain string, ip string, ttl int, comment string) tftypes.Value {
+	return tftypes.NewValue(functionDNSRecordType, map[string]tftypes.Value{
+		"domain":  tftypes.NewValue(tftypes.String, domain),
+		"ip":      tftypes.NewValue(tftypes.String, ip),
+		"ttl":     tftypes.NewValue(tftypes.Number, big.NewFloat(float64(ttl))),
+		"comment": tftypes.NewValue(tftypes.String, comment),
+	})
+}
+
+// newFunctionCNAMERecordValue returns the function value of a CNAME record
+func newFunctionCNAMERecordValue(domain string, target string, ttl int) tftypes.Value {
+	return tftypes.NewValue(functionCNAMERecordType, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, domain),
+		"target": tftypes.NewValue(tftypes.String, target),
+		"ttl":    tftypes.NewValue(tftypes.Number, big.NewFloat(float64(ttl))),
+	})
+}
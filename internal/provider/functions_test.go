@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServerFunctions(t *testing.T) {
+	server := ProviderServer()
+
+	schema, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"parse_dnsmasq", "parse_hosts", "render_hosts"} {
+		if _, ok := schema.Functions[name]; !ok {
+			t.Errorf("expected function %q in the provider schema", name)
+		}
+	}
+
+	if _, ok := schema.ResourceSchemas["pihole_dns_record"]; !ok {
+		t.Error("expected the plugin SDK resources to be served")
+	}
+}
+
+func TestProviderServerCallFunction(t *testing.T) {
+	server := ProviderServer()
+
+	content, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, "10.0.0.1 foo.com # NET-123\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+		Name:      "parse_hosts",
+		Arguments: []*tfprotov5.DynamicValue{&content},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed.Error != nil {
+		t.Fatal(parsed.Error.Text)
+	}
+
+	records, err := parsed.Result.Unmarshal(tftypes.List{ElementType: functionDNSRecordType})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	argument, err := tfprotov5.NewDynamicValue(tftypes.DynamicPseudoType, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+		Name:      "render_hosts",
+		Arguments: []*tfprotov5.DynamicValue{&argument},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rendered.Error != nil {
+		t.Fatal(rendered.Error.Text)
+	}
+
+	result, err := rendered.Result.Unmarshal(tftypes.String)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hosts string
+	if err := result.As(&hosts); err != nil {
+		t.Fatal(err)
+	}
+
+	if hosts != "10.0.0.1 foo.com # NET-123\n" {
+		t.Fatalf("expected hosts file to round trip, got %q", hosts)
+	}
+
+	missing, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{Name: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if missing.Error == nil {
+		t.Fatal("expected unknown function to fail")
+	}
+}
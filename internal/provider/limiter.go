@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestLimiter bounds the number of concurrent requests and the request rate sent to Pi-hole
+type requestLimiter struct {
+	// slots holds a token per in-flight request, nil when concurrency is unlimited
+	slots chan struct{}
+
+	// interval is the minimum delay between two requests, zero when the rate is unlimited
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newRequestLimiter returns a limiter, values lower or equal to zero disable the respective limit
+func newRequestLimiter(maxConcurrent int, perSecond float64) *requestLimiter {
+	limiter := &requestLimiter{}
+
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	if perSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+
+	return limiter
+}
+
+// acquire blocks until a request may be sent, the returned function must be called once the request completes
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		now := time.Now()
+		at := l.next
+		if at.Before(now) {
+			at = now
+		}
+		l.next = at.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := time.Until(at); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return release, nil
+}
+
+// limitedTransport is a http.RoundTripper applying a requestLimiter to every request
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *requestLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// Keep the slot until the response body has been consumed
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+
+	return res, nil
+}
+
+// releasingBody releases a limiter slot once the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
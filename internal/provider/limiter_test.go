@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiterConcurrency(t *testing.T) {
+	limiter := newRequestLimiter(2, 0)
+
+	var inFlight, peak int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			release, err := limiter.acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+
+			current := atomic.AddInt32(&inFlight, 1)
+			for {
+				highest := atomic.LoadInt32(&peak)
+				if current <= highest || atomic.CompareAndSwapInt32(&peak, highest, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+		}()
+	}
+
+	wg.Wait()
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestRequestLimiterRate(t *testing.T) {
+	limiter := newRequestLimiter(0, 100)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected 5 requests at 100/s to take at least 40ms, took %s", elapsed)
+	}
+}
+
+func TestRequestLimiterCanceled(t *testing.T) {
+	limiter := newRequestLimiter(1, 0)
+
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Fatal("expected acquire to fail once the context is canceled")
+	}
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// apiLogSubsystem is the tflog subsystem of Pi-hole API request logs
+	apiLogSubsystem = "pihole_api"
+
+	// apiLogBodyLimit is the maximum number of body bytes included in a log entry
+	apiLogBodyLimit = 4096
+
+	redactedValue = "***"
+)
+
+// redactedHeaders are never logged in clear text
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-FTL-SID", "X-FTL-CSRF"}
+
+// redactedFields are JSON keys whose values are never logged in clear text
+var redactedFields = map[string]bool{
+	"app_pwhash":  true,
+	"csrf":        true,
+	"hash":        true,
+	"password":    true,
+	"pwhash":      true,
+	"sid":         true,
+	"totp":        true,
+	"totp_secret": true,
+}
+
+// retryAttemptHeader carries the retry attempt of a request from the retryablehttp request hook to the loggingTransport.
+// It is removed before the request is sent to Pi-hole.
+const retryAttemptHeader = "X-Terraform-Retry-Attempt"
+
+// logRetryAttempt is a retryablehttp.RequestLogHook recording the attempt number in the retryAttemptHeader
+func logRetryAttempt(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	req.Header.Set(retryAttemptHeader, strconv.Itoa(attempt))
+}
+
+// apiLogBodiesEnabled reports whether Pi-hole API logs are emitted at DEBUG or TRACE level, following the tflog level
+// resolution of the pihole_api subsystem, the provider and Terraform
+func apiLogBodiesEnabled() bool {
+	for _, env := range []string{"TF_LOG_PROVIDER_PIHOLE_API", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := strings.ToUpper(strings.TrimSpace(os.Getenv(env))); level != "" {
+			return level == "DEBUG" || level == "TRACE" || level == "JSON"
+		}
+	}
+
+	return false
+}
+
+// loggingTransport is a http.RoundTripper emitting a tflog entry for every Pi-hole API request
+type loggingTransport struct {
+	base http.RoundTripper
+
+	// logBodies buffers request and response bodies to include them in the log entries,
+	// only enabled when the entries are emitted at all
+	logBodies bool
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), apiLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_PIHOLE_API"))
+
+	attempt, _ := strconv.Atoi(req.Header.Get(retryAttemptHeader))
+	if req.Header.Get(retryAttemptHeader) != "" {
+		req = req.Clone(req.Context())
+		req.Header.Del(retryAttemptHeader)
+	}
+
+	fields := map[string]interface{}{
+		"method":          req.Method,
+		"path":            req.URL.Path,
+		"attempt":         attempt,
+		"request_headers": redactHeaders(req.Header),
+	}
+
+	if len(req.URL.Query()) > 0 {
+		query := req.URL.Query()
+		if query.Has("sid") {
+			query.Set("sid", redactedValue)
+		}
+
+		fields["query"] = query.Encode()
+	}
+
+	if t.logBodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["request_body"] = redactBody(body)
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Sending Pi-hole API request", fields)
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemError(ctx, apiLogSubsystem, "Pi-hole API request failed", fields)
+
+		return nil, err
+	}
+
+	delete(fields, "request_body")
+	delete(fields, "request_headers")
+	fields["status"] = res.StatusCode
+	fields["response_headers"] = redactHeaders(res.Header)
+
+	if t.logBodies {
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		fields["response_body"] = redactBody(body)
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received Pi-hole API response", fields)
+
+	return res, nil
+}
+
+// redactHeaders returns the headers with credentials replaced
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+
+	for key := range headers {
+		redacted[key] = headers.Get(key)
+	}
+
+	for _, key := range redactedHeaders {
+		if headers.Get(key) != "" {
+			redacted[http.CanonicalHeaderKey(key)] = redactedValue
+		}
+	}
+
+	return redacted
+}
+
+// redactBody returns a loggable representation of a JSON body with credentials replaced
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON content>", len(body))
+	}
+
+	redacted, err := json.Marshal(redactValue(payload))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	if len(redacted) > apiLogBodyLimit {
+		return string(redacted[:apiLogBodyLimit]) + "...(truncated)"
+	}
+
+	return string(redacted)
+}
+
+// redactValue walks a decoded JSON value, replacing the values of redactedFields
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redactedFields[key] && field != nil {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
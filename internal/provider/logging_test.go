@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := `{"password":"secret","session":{"sid":"abc","valid":true},"app":{"hash":"h"},"items":[{"totp":123456}]}`
+
+	redacted := redactBody([]byte(body))
+
+	for _, secret := range []string{"secret", "abc", `"h"`, "123456"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("expected %q to be redacted from %s", secret, redacted)
+		}
+	}
+
+	if !strings.Contains(redacted, `"valid":true`) {
+		t.Errorf("expected non-sensitive fields to be kept, got %s", redacted)
+	}
+
+	if redacted := redactBody([]byte("plain text password")); strings.Contains(redacted, "password") {
+		t.Errorf("expected non-JSON bodies not to be logged, got %s", redacted)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-FTL-SID", "abc")
+	headers.Set("User-Agent", "terraform-provider-pihole")
+
+	redacted := redactHeaders(headers)
+
+	if redacted["X-Ftl-Sid"] != redactedValue {
+		t.Errorf("expected session header to be redacted, got %q", redacted["X-Ftl-Sid"])
+	}
+
+	if redacted["User-Agent"] != "terraform-provider-pihole" {
+		t.Errorf("expected user agent to be kept, got %q", redacted["User-Agent"])
+	}
+}
+
+// roundTripperFunc adapts a function to a http.RoundTripper
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// errReader fails the test when a body is read
+type errReader struct {
+	t *testing.T
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	r.t.Error("expected the body not to be buffered")
+	return 0, io.EOF
+}
+
+func TestLoggingTransport(t *testing.T) {
+	for _, logBodies := range []bool{false, true} {
+		var sent *http.Request
+
+		transport := &loggingTransport{
+			logBodies: logBodies,
+			base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sent = req
+
+				body := io.Reader(strings.NewReader(`{"took":0.1}`))
+				if !logBodies {
+					body = errReader{t}
+				}
+
+				return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(body)}, nil
+			}),
+		}
+
+		req, err := http.NewRequest(http.MethodPost, "http://pi.hole/api/auth", strings.NewReader(`{"password":"secret"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !logBodies {
+			req.Body = io.NopCloser(errReader{t})
+		}
+
+		logRetryAttempt(nil, req, 2)
+
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+
+		if sent.Header.Get(retryAttemptHeader) != "" {
+			t.Errorf("expected the retry attempt header not to be sent to Pi-hole")
+		}
+
+		if req.Header.Get(retryAttemptHeader) != "2" {
+			t.Errorf("expected the original request not to be modified by the transport")
+		}
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/markjoyeuxcom/terraform-provider-pihole/internal/version"
 )
 
@@ -19,8 +22,8 @@ func Provider() *schema.Provider {
 				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_PASSWORD", nil),
 				Description:  "The admin password used to login to the admin dashboard.",
 				Sensitive:    true,
-				ExactlyOneOf: []string{"password", "api_token"},
-				AtLeastOneOf: []string{"password", "api_token"},
+				ExactlyOneOf: []string{"password", "api_token", "app_password"},
+				AtLeastOneOf: []string{"password", "api_token", "app_password"},
 			},
 			"api_token": {
 				Type:         schema.TypeString,
@@ -28,8 +31,25 @@ func Provider() *schema.Provider {
 				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_API_TOKEN", nil),
 				Description:  "Pi-hole API token used for token-based authentication.",
 				Sensitive:    true,
-				ExactlyOneOf: []string{"password", "api_token"},
-				AtLeastOneOf: []string{"password", "api_token"},
+				ExactlyOneOf: []string{"password", "api_token", "app_password"},
+				AtLeastOneOf: []string{"password", "api_token", "app_password"},
+			},
+			"app_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_APP_PASSWORD", nil),
+				Description:  "Pi-hole application password used in place of the admin password. Application passwords are not subject to two-factor authentication.",
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "api_token", "app_password"},
+				AtLeastOneOf: []string{"password", "api_token", "app_password"},
+			},
+			"totp_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_TOTP_SECRET", nil),
+				Description:   "Base32 encoded TOTP secret of the admin account. When set, the current TOTP code is generated at login time to satisfy Pi-hole two-factor authentication.",
+				Sensitive:     true,
+				ConflictsWith: []string{"api_token", "app_password"},
 			},
 			"url": {
 				Type:        schema.TypeString,
@@ -43,16 +63,81 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_CA_FILE", nil),
 				Description: "CA file to connect to Pi-hole with TLS",
 			},
+			"batch_window": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("PIHOLE_BATCH_WINDOW", "100ms"),
+				Description:      "Duration during which `pihole_dns_record` and `pihole_cname_record` changes are collected and written to Pi-hole in a single configuration update. Set to `0s` to write changes as they arrive. Defaults to `100ms`.",
+				ValidateDiagFunc: validateDuration,
+			},
+			"default_comment": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("PIHOLE_DEFAULT_COMMENT", nil),
+				Description:      "Comment written to `pihole_dns_record` resources which do not set `comment`. Supports Go templates with the `.Workspace`, `.Resource`, `.Domain` and `.IP` fields, e.g. `managed by terraform ({{ .Workspace }})`. When unset, comments of records which do not set `comment` are left as they are.",
+				ValidateDiagFunc: validateCommentTemplate,
+			},
+			"max_concurrent_requests": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("PIHOLE_MAX_CONCURRENT_REQUESTS", 0),
+				Description:      "Maximum number of concurrent requests sent to Pi-hole. Defaults to `0` (unlimited).",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_READ_CACHE", true),
+				Description: "Fetch the local DNS and CNAME tables once and serve every `pihole_dns_record`, `pihole_cname_record` and data source read from them until the next write. Defaults to `true`.",
+			},
+			"requests_per_second": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("PIHOLE_REQUESTS_PER_SECOND", 0),
+				Description:      "Maximum number of requests per second sent to Pi-hole. Defaults to `0` (unlimited).",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+			},
+			"session_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_SESSION_CACHE", false),
+				Description: "Reuse Pi-hole sessions across provider runs by caching session IDs on disk. Only applies to password authentication.",
+			},
+			"session_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_SESSION_CACHE_DIR", nil),
+				Description: "Directory where cached sessions are stored. Defaults to `terraform-provider-pihole` within the user cache directory.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"pihole_cname_records": dataSourceCNAMERecords(),
-			"pihole_dns_records":   dataSourceDNSRecords(),
+			"pihole_cname_record":       dataSourceCNAMERecord(),
+			"pihole_cname_records":      dataSourceCNAMERecords(),
+			"pihole_dhcp_leases":        dataSourceDHCPLeases(),
+			"pihole_dns_record":         dataSourceDNSRecord(),
+			"pihole_dns_records":        dataSourceDNSRecords(),
+			"pihole_domain_search":      dataSourceDomainSearch(),
+			"pihole_info":               dataSourceInfo(),
+			"pihole_messages":           dataSourceMessages(),
+			"pihole_network_devices":    dataSourceNetworkDevices(),
+			"pihole_network_gateway":    dataSourceNetworkGateway(),
+			"pihole_network_interfaces": dataSourceNetworkInterfaces(),
+			"pihole_network_routes":     dataSourceNetworkRoutes(),
+			"pihole_query_log":          dataSourceQueryLog(),
+			"pihole_sessions":           dataSourceSessions(),
+			"pihole_stats_summary":      dataSourceStatsSummary(),
+			"pihole_top_clients":        dataSourceTopClients(),
+			"pihole_top_domains":        dataSourceTopDomains(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"pihole_cname_record": resourceCNAMERecord(),
-			"pihole_dns_record":   resourceDNSRecord(),
+			"pihole_app_password":            resourceAppPassword(),
+			"pihole_cname_record":            resourceCNAMERecord(),
+			"pihole_dhcp_lease_revocation":   resourceDHCPLeaseRevocation(),
+			"pihole_dns_record":              resourceDNSRecord(),
+			"pihole_message_acknowledgement": resourceMessageAcknowledgement(),
+			"pihole_session_cleanup":         resourceSessionCleanup(),
 		},
 	}
 
@@ -64,19 +149,57 @@ func Provider() *schema.Provider {
 // configure configures a Pi-hole client to be used for terraform resource requests
 func configure(version string, provider *schema.Provider) func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (client interface{}, diags diag.Diagnostics) {
-		client, err := Config{
-			Password:  d.Get("password").(string),
-			APIToken:  d.Get("api_token").(string),
-			URL:       d.Get("url").(string),
-			UserAgent: provider.UserAgent("terraform-provider-pihole", version),
-			CAFile:    d.Get("ca_file").(string),
-			SessionID: os.Getenv("__PIHOLE_SESSION_ID"),
+		var sessionCacheDir string
+		if d.Get("session_cache").(bool) {
+			sessionCacheDir = d.Get("session_cache_dir").(string)
+			if sessionCacheDir == "" {
+				sessionCacheDir = defaultSessionCacheDir()
+			}
+		}
+
+		batchWindow, err := time.ParseDuration(d.Get("batch_window").(string))
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("invalid batch_window: %w", err))
+		}
+
+		client, err = Config{
+			Password:              d.Get("password").(string),
+			APIToken:              d.Get("api_token").(string),
+			AppPassword:           d.Get("app_password").(string),
+			TOTPSecret:            d.Get("totp_secret").(string),
+			URL:                   d.Get("url").(string),
+			UserAgent:             provider.UserAgent("terraform-provider-pihole", version),
+			CAFile:                d.Get("ca_file").(string),
+			SessionID:             os.Getenv("__PIHOLE_SESSION_ID"),
+			SessionCacheDir:       sessionCacheDir,
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+			RequestsPerSecond:     d.Get("requests_per_second").(float64),
+			BatchWindow:           batchWindow,
+			DisableReadCache:      !d.Get("read_cache").(bool),
+			DefaultComment:        d.Get("default_comment").(string),
 		}.Client(ctx)
 
 		if err != nil {
-			return nil, diag.FromErr(fmt.Errorf("failed to instantiate client: %w", err))
+			return nil, diagFromErr(fmt.Errorf("failed to instantiate client: %w", err), nil)
 		}
 
 		return client, diags
 	}
 }
+
+// validateDuration validates that a string attribute is a non-negative Go duration such as "250ms"
+func validateDuration(value interface{}, path cty.Path) diag.Diagnostics {
+	duration, err := time.ParseDuration(value.(string))
+	if err != nil || duration < 0 {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid duration",
+				Detail:        fmt.Sprintf("%q is not a valid non-negative duration, expected a value such as \"250ms\" or \"1s\".", value),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
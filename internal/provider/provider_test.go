@@ -16,8 +16,9 @@ func testAccPreCheck(t *testing.T) {
 
 	password := os.Getenv("PIHOLE_PASSWORD")
 	apiToken := os.Getenv("PIHOLE_API_TOKEN")
-	if password == "" && apiToken == "" {
-		t.Fatal("PIHOLE_PASSWORD or PIHOLE_API_TOKEN must be set for acceptance tests")
+	appPassword := os.Getenv("PIHOLE_APP_PASSWORD")
+	if password == "" && apiToken == "" && appPassword == "" {
+		t.Fatal("PIHOLE_PASSWORD, PIHOLE_API_TOKEN or PIHOLE_APP_PASSWORD must be set for acceptance tests")
 	}
 
 	if password != "" && os.Getenv("__PIHOLE_SESSION_ID") == "" {
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// recordIndex maps record domains, addresses or targets to the values of the records sharing them
+type recordIndex map[string][]string
+
+// recordIndexSchema returns the schema of a computed record index.
+// The plugin SDK cannot store maps of lists, so an index is a list of objects holding a key and its values,
+// which configurations turn into a map with a for expression.
+func recordIndexSchema(description string, key string, keyDescription string, values string, valuesDescription string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				key: {
+					Description: keyDescription,
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				values: {
+					Description: valuesDescription,
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// add records a value for a key, adding the same value twice is a no-op
+func (i recordIndex) add(key string, value string) {
+	if !slices.Contains(i[key], value) {
+		i[key] = append(i[key], value)
+	}
+}
+
+// flatten returns the index as a list of objects sorted by key, each holding the key and its sorted values
+func (i recordIndex) flatten(key string, values string) []map[string]interface{} {
+	keys := make([]string, 0, len(i))
+	for k := range i {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	flattened := make([]map[string]interface{}, len(keys))
+	for n, k := range keys {
+		sorted := slices.Clone(i[k])
+		slices.Sort(sorted)
+
+		flattened[n] = map[string]interface{}{
+			key:    k,
+			values: sorted,
+		}
+	}
+
+	return flattened
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecordIndexFlatten(t *testing.T) {
+	index := recordIndex{}
+	index.add("foo.com", "10.0.0.2")
+	index.add("foo.com", "10.0.0.1")
+	index.add("foo.com", "10.0.0.2")
+	index.add("bar.com", "fd00::1")
+
+	expected := []map[string]interface{}{
+		{"domain": "bar.com", "ips": []string{"fd00::1"}},
+		{"domain": "foo.com", "ips": []string{"10.0.0.1", "10.0.0.2"}},
+	}
+
+	if actual := index.flatten("domain", "ips"); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maskedConfigValue is returned by Pi-hole in place of sensitive configuration values
+const maskedConfigValue = "********"
+
+// resourceAppPassword returns the application password Terraform resource management configuration
+func resourceAppPassword() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the Pi-hole application password. Pi-hole supports a single application password, " +
+			"so at most one instance of this resource may exist per Pi-hole: creating it fails while another application password " +
+			"is active unless `overwrite` is set. Replacing the password invalidates the previous one and every session opened with it.",
+		CreateContext: resourceAppPasswordCreate,
+		ReadContext:   resourceAppPasswordRead,
+		DeleteContext: resourceAppPasswordDelete,
+		Schema: map[string]*schema.Schema{
+			"rotation_trigger": {
+				Description: "Arbitrary map of values that, when changed, generates a new application password",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"overwrite": {
+				Description: "Replace an application password that is already active on Pi-hole, e.g. one generated in the web interface. " +
+					"Required when the resource is replaced with `create_before_destroy`. Defaults to `false`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"password": {
+				Description: "Generated application password",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"hash": {
+				Description: "Hash of the application password as stored by Pi-hole",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// appPasswordResponse is the response body of the /api/auth/app endpoint
+type appPasswordResponse struct {
+	App struct {
+		Password string `json:"password"`
+		Hash     string `json:"hash"`
+	} `json:"app"`
+}
+
+// resourceAppPasswordCreate generates a new application password and activates it
+func resourceAppPasswordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+
+	// Pi-hole keeps a single application password, a second instance would silently invalidate the first
+	if !d.Get("overwrite").(bool) {
+		hash, err := getAppPasswordHash(ctx, client)
+		if err != nil {
+			return diagFromErr(err, d)
+		}
+
+		if hash != "" {
+			return diag.Errorf("Pi-hole already has an active application password, which is managed by another pihole_app_password " +
+				"resource or was generated in the web interface. Only one pihole_app_password resource may exist per Pi-hole; " +
+				"set overwrite = true to replace the active application password.")
+		}
+	}
+
+	var res appPasswordResponse
+	if err := client.api.do(ctx, http.MethodGet, "/api/auth/app", nil, &res); err != nil {
+		return diagFromErr(err, d)
+	}
+
+	if err := setAppPasswordHash(ctx, client, res.App.Hash); err != nil {
+		return diagFromErr(err, d)
+	}
+
+	if err := d.Set("password", res.App.Password); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("hash", res.App.Hash); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(res.App.Hash)))[:16])
+
+	return diags
+}
+
+// resourceAppPasswordRead removes the application password from state when it was replaced or disabled outside of Terraform
+func resourceAppPasswordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	active, err := appPasswordActive(ctx, client, d)
+	if err != nil {
+		return diagFromErr(err, d)
+	}
+
+	if !active {
+		d.SetId("")
+		return nil
+	}
+
+	return diags
+}
+
+// resourceAppPasswordDelete disables the application password
+func resourceAppPasswordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+
+	active, err := appPasswordActive(ctx, client, d)
+	if err != nil {
+		return diagFromErr(err, d)
+	}
+
+	// Leave a password that was replaced by another resource instance or outside of Terraform in place
+	if active {
+		if err := setAppPasswordHash(ctx, client, ""); err != nil {
+			return diagFromErr(err, d)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// appPasswordActive reports whether the application password in state is still the one active on Pi-hole.
+// The hash is compared when Pi-hole reveals it; when it is masked, a login with the password decides instead.
+func appPasswordActive(ctx context.Context, client *Client, d *schema.ResourceData) (bool, error) {
+	hash, err := getAppPasswordHash(ctx, client)
+	if err != nil || hash == "" {
+		return false, err
+	}
+
+	if hash != maskedConfigValue {
+		return hash == d.Get("hash").(string), nil
+	}
+
+	api := client.api.withSession("")
+
+	s, err := api.login(ctx, d.Get("password").(string), "")
+	if err != nil {
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if err := api.logout(ctx, s.SID); err != nil {
+		log.Printf("[WARN] Failed to close Pi-hole session opened to verify the application password: %s", err)
+	}
+
+	return true, nil
+}
+
+// getAppPasswordHash returns the hash of the active application password, empty when none is set
+func getAppPasswordHash(ctx context.Context, client *Client) (string, error) {
+	var res struct {
+		Config struct {
+			Webserver struct {
+				API struct {
+					AppPwhash string `json:"app_pwhash"`
+				} `json:"api"`
+			} `json:"webserver"`
+		} `json:"config"`
+	}
+
+	if err := client.api.getConfig(ctx, "webserver.api.app_pwhash", &res); err != nil {
+		return "", err
+	}
+
+	return res.Config.Webserver.API.AppPwhash, nil
+}
+
+// setAppPasswordHash activates the application password with the passed hash, an empty hash disables it
+func setAppPasswordHash(ctx context.Context, client *Client, hash string) error {
+	return client.api.patchConfig(ctx, map[string]interface{}{
+		"webserver": map[string]interface{}{
+			"api": map[string]interface{}{
+				"app_pwhash": hash,
+			},
+		},
+	})
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccAppPassword acceptance test for the application password resource
+func TestAccAppPassword(t *testing.T) {
+	var password string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAppPasswordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAppPasswordResourceConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("pihole_app_password.app", "password"),
+					resource.TestCheckResourceAttrSet("pihole_app_password.app", "hash"),
+					resource.TestCheckResourceAttrWith("pihole_app_password.app", "password", func(value string) error {
+						password = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAppPasswordResourceConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("pihole_app_password.app", "password", func(value string) error {
+						if value == password {
+							return fmt.Errorf("expected application password to be rotated")
+						}
+
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAppPasswordResourceConfig returns HCL to configure an application password
+func testAppPasswordResourceConfig(rotation string) string {
+	return fmt.Sprintf(`
+		resource "pihole_app_password" "app" {
+			rotation_trigger = {
+				rotation = %q
+			}
+		}
+	`, rotation)
+}
+
+// testAccCheckAppPasswordDestroy checks that the application password was disabled
+func testAccCheckAppPasswordDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_app_password" {
+			continue
+		}
+
+		hash, err := getAppPasswordHash(context.Background(), client)
+		if err != nil {
+			return err
+		}
+
+		if hash != "" {
+			return fmt.Errorf("expected application password to be disabled")
+		}
+	}
+
+	return nil
+}
+
+// testAppPasswordServer emulates the Pi-hole endpoints used by the application password resource.
+// The active hash is reported as configured, or masked when masked is set.
+func testAppPasswordServer(t *testing.T, hash *string, password string, masked bool) *Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/config/webserver/api/app_pwhash":
+			reported := *hash
+			if masked && reported != "" {
+				reported = maskedConfigValue
+			}
+
+			fmt.Fprintf(w, `{"config":{"webserver":{"api":{"app_pwhash":%q}}}}`, reported)
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/config":
+			var body struct {
+				Config struct {
+					Webserver struct {
+						API struct {
+							AppPwhash string `json:"app_pwhash"`
+						} `json:"api"`
+					} `json:"webserver"`
+				} `json:"config"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode config patch: %s", err)
+			}
+
+			*hash = body.Config.Webserver.API.AppPwhash
+		case r.Method == http.MethodGet && r.URL.Path == "/api/auth/app":
+			fmt.Fprint(w, `{"app":{"password":"generated","hash":"$BALLOON-SHA256$new"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/auth":
+			var body struct {
+				Password string `json:"password"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode login: %s", err)
+			}
+
+			if body.Password != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":{"key":"unauthorized","message":"Unauthorized","hint":null}}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"session":{"valid":true,"totp":false,"sid":"verify","validity":300}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/auth":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"session":{"valid":false,"totp":false,"sid":null,"validity":-1}}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/auth":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	return &Client{api: &apiClient{baseURL: ts.URL, http: ts.Client()}}
+}
+
+func TestAppPasswordDeleteKeepsReplacement(t *testing.T) {
+	for _, masked := range []bool{false, true} {
+		t.Run(fmt.Sprintf("masked=%t", masked), func(t *testing.T) {
+			// The replacing instance already activated its password, the old instance is deleted afterwards
+			hash := "$BALLOON-SHA256$new"
+			client := testAppPasswordServer(t, &hash, "new-password", masked)
+
+			d := schema.TestResourceDataRaw(t, resourceAppPassword().Schema, map[string]interface{}{})
+			d.SetId("old")
+			_ = d.Set("hash", "$BALLOON-SHA256$old")
+			_ = d.Set("password", "old-password")
+
+			if diags := resourceAppPasswordDelete(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if hash != "$BALLOON-SHA256$new" {
+				t.Errorf("expected the replacement password to be kept, got hash %q", hash)
+			}
+
+			// Deleting the instance that owns the active password disables it
+			d.SetId("new")
+			_ = d.Set("hash", "$BALLOON-SHA256$new")
+			_ = d.Set("password", "new-password")
+
+			if diags := resourceAppPasswordDelete(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if hash != "" {
+				t.Errorf("expected the application password to be disabled, got hash %q", hash)
+			}
+		})
+	}
+}
+
+func TestAppPasswordCreateRejectsActivePassword(t *testing.T) {
+	hash := "$BALLOON-SHA256$existing"
+	client := testAppPasswordServer(t, &hash, "", true)
+
+	d := schema.TestResourceDataRaw(t, resourceAppPassword().Schema, map[string]interface{}{})
+
+	diags := resourceAppPasswordCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already has an active application password") {
+		t.Fatalf("expected creation to be rejected, got %v", diags)
+	}
+
+	if hash != "$BALLOON-SHA256$existing" {
+		t.Errorf("expected the active password to be kept, got hash %q", hash)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceAppPassword().Schema, map[string]interface{}{"overwrite": true})
+
+	if diags := resourceAppPasswordCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if hash != "$BALLOON-SHA256$new" {
+		t.Errorf("expected the active password to be replaced, got hash %q", hash)
+	}
+}
@@ -3,18 +3,17 @@ package provider
 import (
 	"context"
 	"errors"
-	"sync"
+	"fmt"
 	"time"
 
 	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var resourceDeleteMutex sync.Mutex
-
 // resourceCNAMERecord returns the CNAME Terraform resource management configuration
 func resourceCNAMERecord() *schema.Resource {
 	return &schema.Resource{
@@ -22,21 +21,28 @@ func resourceCNAMERecord() *schema.Resource {
 		CreateContext: resourceCNAMERecordCreate,
 		ReadContext:   resourceCNAMERecordRead,
 		DeleteContext: resourceCNAMERecordDelete,
+		CustomizeDiff: resourceCNAMERecordCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"domain": {
-				Description: "Domain to create a CNAME record for",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "Domain to create a CNAME record for. Normalized to lowercase punycode without a trailing dot.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDomain,
+				StateFunc:        normalizeDomainState,
+				DiffSuppressFunc: suppressEquivalentDomainDiff,
 			},
 			"target": {
-				Description: "Value of the CNAME record where traffic will be directed to from the configured domain value",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "Value of the CNAME record where traffic will be directed to from the configured domain value. Pi-hole only answers CNAME queries for targets it has a local record for, a warning is logged at plan time when the target has none. Normalized to lowercase punycode without a trailing dot.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDomain,
+				StateFunc:        normalizeDomainState,
+				DiffSuppressFunc: suppressEquivalentDomainDiff,
 			},
 			"ttl": {
 				Description:      "Optional TTL (in seconds) for the CNAME record.",
@@ -52,50 +58,94 @@ func resourceCNAMERecord() *schema.Resource {
 
 // resourceCNAMERecordCreate handles the creation a CNAME record via Terraform
 func resourceCNAMERecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	domain := d.Get("domain").(string)
-	target := d.Get("target").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
+
+	if err := client.createCNAMERecord(ctx, cnameRecordFromResourceData(d)); err != nil {
+		return diagFromErr(err, d, "target", "domain")
+	}
+
+	if err := waitForCNAMERecord(ctx, client, domain); err != nil {
+		return diagFromErr(err, d)
+	}
+
+	d.SetId(domain)
+
+	return diags
+}
+
+// cnameRecordFromResourceData returns the CNAME record configured by a resource
+func cnameRecordFromResourceData(d *schema.ResourceData) pihole.CNAMERecord {
+	record := pihole.CNAMERecord{
+		Domain: normalizeDomain(d.Get("domain").(string)),
+		Target: normalizeDomain(d.Get("target").(string)),
+	}
 
-	record := &pihole.CNAMERecord{Domain: domain, Target: target}
 	if ttl, ok := d.GetOk("ttl"); ok {
 		record.TTL = ttl.(int)
 		record.HasTTL = true
 	}
 
-	if _, err := client.LocalCNAME.CreateRecord(ctx, record); err != nil {
-		if !errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
-			return diag.FromErr(err)
-		}
+	return record
+}
+
+// resourceCNAMERecordCustomizeDiff rejects CNAME records targeting their own domain and warns about targets without a local record
+func resourceCNAMERecordCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	domain := diff.Get("domain").(string)
+	target := diff.Get("target").(string)
+
+	if domain != "" && target != "" && sameDomain(domain, target) {
+		return fmt.Errorf("CNAME record %q must not target its own domain", domain)
 	}
 
-	if err := waitForCNAMERecord(ctx, client, domain); err != nil {
-		return diag.FromErr(err)
+	if client, ok := meta.(*Client); ok && target != "" && diff.HasChange("target") {
+		checkCNAMETarget(ctx, client, target)
 	}
 
-	d.SetId(domain)
+	return nil
+}
 
-	return diags
+// checkCNAMETarget warns when the target has no local DNS or CNAME record, as Pi-hole only resolves CNAMEs to known names.
+// The plugin SDK cannot return warnings from a plan, so the warning is written to the provider log.
+func checkCNAMETarget(ctx context.Context, client *Client, target string) {
+	config, err := client.cache.get(ctx)
+	if err != nil {
+		return
+	}
+
+	if _, err := config.findDNSRecord(target); err == nil {
+		return
+	}
+
+	if _, err := config.findCNAMERecord(target); err == nil {
+		return
+	}
+
+	tflog.Warn(ctx, "CNAME target has no local record. Pi-hole only answers CNAME queries for targets it knows locally, "+
+		"queries for this record may return no answer unless the target is created in the same run.", map[string]interface{}{
+		"target": target,
+	})
 }
 
 // resourceCNAMERecordRead retrieves the CNAME record of the associated domain ID
 func resourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	record, err := client.LocalCNAME.Get(ctx, d.Id())
+	record, err := client.getCNAMERecord(ctx, d.Id())
 	if err != nil {
 		if errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
 			d.SetId("")
 			return nil
 		}
 
-		return diag.FromErr(err)
+		return diagFromErr(err, d)
 	}
 
 	if err = d.Set("domain", record.Domain); err != nil {
@@ -121,16 +171,13 @@ func resourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta i
 
 // resourceCNAMERecordDelete handles the deletion of a CNAME record via Terraform
 func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	resourceDeleteMutex.Lock()
-	defer resourceDeleteMutex.Unlock()
-
-	if err := client.LocalCNAME.Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	if err := client.deleteCNAMERecord(ctx, d.Id()); err != nil {
+		return diagFromErr(err, d)
 	}
 
 	d.SetId("")
@@ -138,9 +185,14 @@ func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta
 	return diags
 }
 
-func waitForCNAMERecord(ctx context.Context, client *pihole.Client, domain string) error {
+func waitForCNAMERecord(ctx context.Context, client *Client, domain string) error {
 	return resource.RetryContext(ctx, 10*time.Second, func() *resource.RetryError {
-		if _, err := client.LocalCNAME.Get(ctx, domain); err != nil {
+		config, err := client.api.getDNSConfig(ctx)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if _, err := config.findCNAMERecord(domain); err != nil {
 			if errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
 				return resource.RetryableError(err)
 			}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
 	pihole "github.com/awaybreaktoday/lib-pihole-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -50,6 +52,35 @@ func TestAccCNAMERecord(t *testing.T) {
 	})
 }
 
+// TestAccCNAMERecordValidation acceptance test for plan-time validation of the CNAME record resource
+func TestAccCNAMERecordValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testLocalCNAMEResourceConfig("foo", "foo..com", "bar.com"),
+				ExpectError: regexp.MustCompile("Invalid domain"),
+			},
+			{
+				Config:      testLocalCNAMEResourceConfig("foo", "foo.com", "FOO.com."),
+				ExpectError: regexp.MustCompile("must not target its own domain"),
+			},
+			{
+				// Pi-hole has no place to store comments of CNAME records
+				Config: `
+					resource "pihole_cname_record" "foo" {
+						domain  = "foo.com"
+						target  = "bar.com"
+						comment = "managed by terraform"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Unsupported argument"),
+			},
+		},
+	})
+}
+
 // testLocalCNAMEResourceConfig returns HCL to configure a CNAME record
 func testLocalCNAMEResourceConfig(name string, domain string, target string, ttl ...int) string {
 	var ttlConfig string
@@ -107,7 +138,7 @@ func testLocalCNAMEResourceConfig(name string, domain string, target string, ttl
 // testCheckLocalCNAMEResourceExists checks that the CNAME record exists in Pi-hole
 func testCheckLocalCNAMEResourceExists(_ *testing.T, domain string, target string) resource.TestCheckFunc {
 	return func(*terraform.State) error {
-		client := testAccProvider.Meta().(*pihole.Client)
+		client := testAccProvider.Meta().(*Client)
 
 		record, err := client.LocalCNAME.Get(context.Background(), domain)
 		if err != nil {
@@ -124,7 +155,7 @@ func testCheckLocalCNAMEResourceExists(_ *testing.T, domain string, target strin
 
 // testAccCheckCNAMERecordDestroy checks that all resources have been deleted
 func testAccCheckCNAMERecordDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*pihole.Client)
+	client := testAccProvider.Meta().(*Client)
 
 	for _, r := range s.RootModule().Resources {
 		if r.Type != "pihole_cname_record" {
@@ -139,3 +170,19 @@ func testAccCheckCNAMERecordDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestCNAMERecordReadKeepsTTL(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{CNAMERecords: []string{"alias.com,foo.com,60"}}}
+	client := testBatchClient(t, server, 0)
+
+	d := schema.TestResourceDataRaw(t, resourceCNAMERecord().Schema, map[string]interface{}{})
+	d.SetId("alias.com")
+
+	if diags := resourceCNAMERecordRead(context.Background(), d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if d.Get("target").(string) != "foo.com" || d.Get("ttl").(int) != 60 {
+		t.Fatalf("unexpected state target=%q ttl=%v", d.Get("target"), d.Get("ttl"))
+	}
+}
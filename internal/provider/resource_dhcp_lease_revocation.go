@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceDHCPLeaseRevocation returns the DHCP lease revocation Terraform resource management configuration
+func resourceDHCPLeaseRevocation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Revokes the active DHCP lease of an IP address when created, forcing the client to request a new lease. " +
+			"Destroying the resource only removes it from state.",
+		CreateContext: resourceDHCPLeaseRevocationCreate,
+		ReadContext:   resourceDHCPLeaseRevocationRead,
+		DeleteContext: resourceDHCPLeaseRevocationDelete,
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Description:      "IP address of the lease to revoke",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, revokes the lease again",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"revoked": {
+				Description: "Whether a lease was active and revoked, `false` when the IP address had no lease",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceDHCPLeaseRevocationCreate revokes the lease of the configured IP address
+func resourceDHCPLeaseRevocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	ip := d.Get("ip").(string)
+
+	revoked := true
+	if err := client.api.do(ctx, http.MethodDelete, "/api/dhcp/leases/"+url.PathEscape(ip), nil, nil); err != nil {
+		if !isNotFoundError(err) {
+			return diagFromErr(err, d, "ip")
+		}
+
+		revoked = false
+	}
+
+	if err := d.Set("revoked", revoked); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ip)
+
+	return diags
+}
+
+// resourceDHCPLeaseRevocationRead keeps the revocation in state, it has no remote counterpart
+func resourceDHCPLeaseRevocationRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceDHCPLeaseRevocationDelete removes the revocation from state
+func resourceDHCPLeaseRevocationDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
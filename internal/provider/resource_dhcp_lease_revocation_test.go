@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDHCPLeaseRevocation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_dhcp_lease_revocation" "missing" {
+					  ip = "192.0.2.1"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_lease_revocation.missing", "id", "192.0.2.1"),
+					resource.TestCheckResourceAttr("pihole_dhcp_lease_revocation.missing", "revoked", "false"),
+				),
+			},
+		},
+	})
+}
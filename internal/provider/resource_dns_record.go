@@ -3,12 +3,14 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	pihole "github.com/awaybreaktoday/lib-pihole-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // resourceDNSRecord returns the local DNS Terraform resource management configuration
@@ -17,32 +19,50 @@ func resourceDNSRecord() *schema.Resource {
 		Description:   "Manages a Pi-hole DNS record",
 		CreateContext: resourceDNSRecordCreate,
 		ReadContext:   resourceDNSRecordRead,
+		UpdateContext: resourceDNSRecordUpdate,
 		DeleteContext: resourceDNSRecordDelete,
+		CustomizeDiff: resourceDNSRecordCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"domain": {
-				Description: "DNS record domain",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "DNS record domain. Normalized to lowercase punycode without a trailing dot.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDomain,
+				StateFunc:        normalizeDomainState,
+				DiffSuppressFunc: suppressEquivalentDomainDiff,
 			},
 			"ip": {
-				Description: "IP address to route traffic to from the DNS record domain",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "IPv4 or IPv6 address to route traffic to from the DNS record domain",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+			},
+			"host_record": {
+				Description: "Store the record as a dnsmasq `host-record=<domain>,<ip>[,<ttl>]` line in `misc.dnsmasq_lines` instead of an entry of `dns.hosts`, which is required to set `ttl`. " +
+					"Host records are not listed under Local DNS Records in the Pi-hole web interface, and changing them rewrites `misc.dnsmasq_lines`. " +
+					"Removing the argument moves the record back to `dns.hosts`. Defaults to `false`.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"ttl": {
-				Description: "TTL (in seconds) reported by Pi-hole for the DNS record.",
-				Type:        schema.TypeInt,
-				Computed:    true,
+				Description:      "Optional TTL (in seconds) for the DNS record, requires `host_record`. Removing the argument or setting it to `0` uses the Pi-hole default TTL.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 			},
 			"comment": {
-				Description: "Comment returned by Pi-hole for the DNS record, if present.",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:      "Comment stored after a `#` on the record's `dns.hosts` entry (`<ip> <domain> # <comment>`), or on its `host-record` line with `host_record`. Defaults to the provider `default_comment`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringDoesNotContainAny("\r\n")),
 			},
 		},
 	}
@@ -50,22 +70,19 @@ func resourceDNSRecord() *schema.Resource {
 
 // resourceDNSRecordCreate handles the creation a local DNS record via Terraform
 func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	domain := d.Get("domain").(string)
-	ip := d.Get("ip").(string)
+	domain := normalizeDomain(d.Get("domain").(string))
 
-	if _, err := client.LocalDNS.Create(ctx, domain, ip); err != nil {
-		if !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
-			return diag.FromErr(err)
-		}
+	if err := client.createDNSRecord(ctx, dnsRecordFromResourceData(d), d.Get("host_record").(bool)); err != nil {
+		return diagFromErr(err, d, "ip", "domain")
 	}
 
 	if err := waitForDNSRecord(ctx, client, domain); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err, d)
 	}
 
 	d.SetId(domain)
@@ -73,20 +90,64 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta i
 	return diags
 }
 
+// dnsRecordFromResourceData returns the local DNS record configured by a resource
+func dnsRecordFromResourceData(d *schema.ResourceData) pihole.DNSRecord {
+	return pihole.DNSRecord{
+		Domain:  normalizeDomain(d.Get("domain").(string)),
+		IP:      d.Get("ip").(string),
+		TTL:     d.Get("ttl").(int),
+		Comment: d.Get("comment").(string),
+	}
+}
+
+// resourceDNSRecordCustomizeDiff rejects TTLs on records stored in dns.hosts, resets the TTL when it is removed from
+// the configuration and plans the provider default_comment when no comment is configured
+func resourceDNSRecordCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	ttl := diff.GetRawConfig().GetAttr("ttl")
+
+	if ttl.IsNull() {
+		// ttl is computed, so without this the TTL of a host record would be kept after removing the argument
+		if diff.Get("ttl").(int) != 0 {
+			if err := diff.SetNew("ttl", 0); err != nil {
+				return err
+			}
+		}
+	} else if ttl.IsKnown() && diff.Get("ttl").(int) > 0 && diff.NewValueKnown("host_record") && !diff.Get("host_record").(bool) {
+		return fmt.Errorf("ttl requires host_record = true, as Pi-hole dns.hosts entries cannot carry a TTL")
+	}
+
+	data := commentData{
+		Resource: "pihole_dns_record",
+		Domain:   normalizeDomain(diff.Get("domain").(string)),
+		IP:       diff.Get("ip").(string),
+	}
+
+	return planComment(diff, meta, data, "domain", "ip")
+}
+
 // resourceDNSRecordRead finds a local DNS record based on the associated domain ID
 func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	record, err := client.LocalDNS.Get(ctx, d.Id())
+	config, err := client.cache.get(ctx)
+	if err != nil {
+		return diagFromErr(err, d)
+	}
+
+	record, err := config.findDNSRecord(d.Id())
 	if err != nil {
 		if errors.Is(err, pihole.ErrorLocalDNSNotFound) {
 			d.SetId("")
 			return nil
 		}
 
+		return diagFromErr(err, d)
+	}
+
+	if err = d.Set("host_record", config.hasHostRecord(d.Id())); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -109,15 +170,29 @@ func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta int
 	return diags
 }
 
+// resourceDNSRecordUpdate handles in-place TTL, comment and storage changes of a local DNS record via Terraform
+func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := client.updateDNSRecord(ctx, dnsRecordFromResourceData(d), d.Get("host_record").(bool)); err != nil {
+		return diagFromErr(err, d, "comment")
+	}
+
+	return diags
+}
+
 // resourceDNSRecordDelete handles the deletion of a local DNS record via Terraform
 func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := meta.(*Client)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	if err := client.LocalDNS.Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	if err := client.deleteDNSRecord(ctx, d.Id()); err != nil {
+		return diagFromErr(err, d)
 	}
 
 	d.SetId("")
@@ -125,9 +200,14 @@ func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta i
 	return diags
 }
 
-func waitForDNSRecord(ctx context.Context, client *pihole.Client, domain string) error {
+func waitForDNSRecord(ctx context.Context, client *Client, domain string) error {
 	return resource.RetryContext(ctx, 10*time.Second, func() *resource.RetryError {
-		if _, err := client.LocalDNS.Get(ctx, domain); err != nil {
+		config, err := client.api.getDNSConfig(ctx)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if _, err := config.findDNSRecord(domain); err != nil {
 			if errors.Is(err, pihole.ErrorLocalDNSNotFound) {
 				return resource.RetryableError(err)
 			}
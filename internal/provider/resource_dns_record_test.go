@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
 	pihole "github.com/awaybreaktoday/lib-pihole-go"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -41,6 +46,180 @@ func TestAccLocalDNS(t *testing.T) {
 	})
 }
 
+func TestAccLocalDNSValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testLocalDNSResourceConfig("foo", "foo.com", "192.168.1.300"),
+				ExpectError: regexp.MustCompile("expected ip to contain a valid IP"),
+			},
+			{
+				Config:      testLocalDNSResourceConfig("foo", "foo..com", "192.168.1.30"),
+				ExpectError: regexp.MustCompile("Invalid domain"),
+			},
+		},
+	})
+}
+
+func TestAccLocalDNSTTL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLocalDNSDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLocalDNSResourceTTLConfig(300),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "ttl", "300"),
+					testCheckLocalDNSResourceExists(t, "foo.com", "127.0.0.1"),
+					testCheckLocalDNSHostRecordTTL("foo.com", 300),
+				),
+			},
+			{
+				Config: testLocalDNSResourceTTLConfig(5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "ttl", "5"),
+					testCheckLocalDNSHostRecordTTL("foo.com", 5),
+				),
+			},
+			{
+				Config: testLocalDNSResourceTTLConfig(0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "ttl", "0"),
+					testCheckLocalDNSHostRecordTTL("foo.com", 0),
+				),
+			},
+			{
+				Config: testLocalDNSResourceConfig("foo", "foo.com", "127.0.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "host_record", "false"),
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "ttl", "0"),
+					testCheckLocalDNSResourceExists(t, "foo.com", "127.0.0.1"),
+				),
+			},
+			{
+				Config:      testLocalDNSResourceTTLConfig(-1),
+				ExpectError: regexp.MustCompile("expected ttl to be at least"),
+			},
+		},
+	})
+}
+
+func TestDNSRecordCustomizeDiffTTL(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "foo.com",
+		Attributes: map[string]string{
+			"id":          "foo.com",
+			"domain":      "foo.com",
+			"ip":          "10.0.0.1",
+			"host_record": "true",
+			"ttl":         "30",
+			"comment":     "",
+		},
+	}
+
+	// Removing ttl and host_record from the configuration plans the record back into dns.hosts
+	state.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		"domain":      cty.StringVal("foo.com"),
+		"ip":          cty.StringVal("10.0.0.1"),
+		"host_record": cty.NullVal(cty.Bool),
+		"ttl":         cty.NullVal(cty.Number),
+		"comment":     cty.NullVal(cty.String),
+	})
+
+	diff, err := resourceDNSRecord().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"domain": "foo.com",
+		"ip":     "10.0.0.1",
+	}), &Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff == nil || diff.Attributes["ttl"] == nil || diff.Attributes["ttl"].New != "0" {
+		t.Fatalf("expected ttl to be reset to 0, got %+v", diff)
+	}
+
+	if diff.Attributes["host_record"] == nil || diff.Attributes["host_record"].New != "false" {
+		t.Fatalf("expected host_record to be reset to false, got %+v", diff.Attributes["host_record"])
+	}
+
+	if diff.RequiresNew() {
+		t.Fatal("expected the record to be updated in place")
+	}
+
+	// A TTL without host_record is rejected at plan time
+	state.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		"domain":      cty.StringVal("foo.com"),
+		"ip":          cty.StringVal("10.0.0.1"),
+		"host_record": cty.NullVal(cty.Bool),
+		"ttl":         cty.NumberIntVal(30),
+		"comment":     cty.NullVal(cty.String),
+	})
+
+	_, err = resourceDNSRecord().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"domain": "foo.com",
+		"ip":     "10.0.0.1",
+		"ttl":    30,
+	}), &Client{})
+	if err == nil || !strings.Contains(err.Error(), "ttl requires host_record") {
+		t.Fatalf("expected ttl without host_record to be rejected, got %v", err)
+	}
+}
+
+func testLocalDNSResourceTTLConfig(ttl int) string {
+	return fmt.Sprintf(`
+		resource "pihole_dns_record" "foo" {
+			domain      = "foo.com"
+			ip          = "127.0.0.1"
+			host_record = true
+			ttl         = %d
+		}
+	`, ttl)
+}
+
+func TestAccLocalDNSComment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLocalDNSDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLocalDNSResourceCommentConfig("managed by terraform - ticket NET-123"),
+				Check:  resource.TestCheckResourceAttr("pihole_dns_record.foo", "comment", "managed by terraform - ticket NET-123"),
+			},
+			{
+				Config: testLocalDNSResourceCommentConfig("managed by terraform - ticket NET-456"),
+				Check:  resource.TestCheckResourceAttr("pihole_dns_record.foo", "comment", "managed by terraform - ticket NET-456"),
+			},
+			{
+				Config: `
+					provider "pihole" {
+						default_comment = "{{ .Resource }} {{ .Domain }} in {{ .Workspace }}"
+					}
+
+					resource "pihole_dns_record" "foo" {
+						domain = "foo.com"
+						ip     = "127.0.0.1"
+					}
+				`,
+				Check: resource.TestCheckResourceAttr("pihole_dns_record.foo", "comment", "pihole_dns_record foo.com in default"),
+			},
+		},
+	})
+}
+
+func testLocalDNSResourceCommentConfig(comment string) string {
+	return fmt.Sprintf(`
+		resource "pihole_dns_record" "foo" {
+			domain  = "foo.com"
+			ip      = "127.0.0.1"
+			comment = %q
+		}
+	`, comment)
+}
+
 func testLocalDNSResourceConfig(name string, domain string, ip string) string {
 	return fmt.Sprintf(`
 		resource "pihole_dns_record" %q {
@@ -52,9 +231,15 @@ func testLocalDNSResourceConfig(name string, domain string, ip string) string {
 
 func testCheckLocalDNSResourceExists(_ *testing.T, domain string, ip string) resource.TestCheckFunc {
 	return func(*terraform.State) error {
-		client := testAccProvider.Meta().(*pihole.Client)
+		client := testAccProvider.Meta().(*Client)
 
-		record, err := client.LocalDNS.Get(context.Background(), domain)
+		// The DNS configuration is read directly, as host records are not returned by the library
+		config, err := client.api.getDNSConfig(context.Background())
+		if err != nil {
+			return err
+		}
+
+		record, err := config.findDNSRecord(domain)
 		if err != nil {
 			return err
 		}
@@ -67,20 +252,79 @@ func testCheckLocalDNSResourceExists(_ *testing.T, domain string, ip string) res
 	}
 }
 
+// testCheckLocalDNSHostRecordTTL checks that the record of a domain is stored as a host record with the TTL
+func testCheckLocalDNSHostRecordTTL(domain string, ttl int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+
+		config, err := client.api.getDNSConfig(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, line := range config.DNSMasqLines {
+			record, ok := parseHostRecord(line)
+			if ok && slices.ContainsFunc(record.Domains, func(d string) bool { return sameDomain(d, domain) }) {
+				if record.TTL != ttl {
+					return fmt.Errorf("host record %q has TTL %d, expected %d", line, record.TTL, ttl)
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no host record for %s in misc.dnsmasq_lines %q", domain, config.DNSMasqLines)
+	}
+}
+
 func testAccCheckLocalDNSDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*pihole.Client)
+	client := testAccProvider.Meta().(*Client)
+
+	config, err := client.api.getDNSConfig(context.Background())
+	if err != nil {
+		return err
+	}
 
 	for _, r := range s.RootModule().Resources {
 		if r.Type != "pihole_dns_record" {
 			continue
 		}
 
-		if _, err := client.LocalDNS.Get(context.Background(), r.Primary.ID); err != nil {
-			if !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
-				return err
-			}
+		if _, err := config.findDNSRecord(r.Primary.ID); err == nil {
+			return fmt.Errorf("local DNS record %s still exists", r.Primary.ID)
+		} else if !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
+			return err
 		}
 	}
 
 	return nil
 }
+
+func TestDNSRecordReadKeepsTTLAndComment(t *testing.T) {
+	server := &testDNSConfigServer{config: dnsConfig{
+		Hosts:        []string{"10.0.0.1 foo.com # set in the web interface"},
+		DNSMasqLines: []string{"host-record=bar.com,10.0.0.2,30 # failover"},
+	}}
+	client := testBatchClient(t, server, 0)
+
+	for _, tc := range []struct {
+		domain     string
+		ttl        int
+		comment    string
+		hostRecord bool
+	}{
+		{domain: "foo.com", comment: "set in the web interface"},
+		{domain: "bar.com", ttl: 30, comment: "failover", hostRecord: true},
+	} {
+		d := schema.TestResourceDataRaw(t, resourceDNSRecord().Schema, map[string]interface{}{})
+		d.SetId(tc.domain)
+
+		if diags := resourceDNSRecordRead(context.Background(), d, client); diags.HasError() {
+			t.Fatalf("%s: %v", tc.domain, diags)
+		}
+
+		if d.Get("ttl").(int) != tc.ttl || d.Get("comment").(string) != tc.comment || d.Get("host_record").(bool) != tc.hostRecord {
+			t.Fatalf("%s: unexpected state ttl=%v comment=%q host_record=%v", tc.domain, d.Get("ttl"), d.Get("comment"), d.Get("host_record"))
+		}
+	}
+}
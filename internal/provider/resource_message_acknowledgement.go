@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceMessageAcknowledgement returns the diagnosis message acknowledgement Terraform resource management configuration
+func resourceMessageAcknowledgement() *schema.Resource {
+	return &schema.Resource{
+		Description: "Acknowledges FTL diagnosis messages by deleting them from the Pi-hole dashboard when created. " +
+			"Destroying the resource only removes it from state.",
+		CreateContext: resourceMessageAcknowledgementCreate,
+		ReadContext:   resourceMessageAcknowledgementRead,
+		DeleteContext: resourceMessageAcknowledgementDelete,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Description: "IDs of the messages to acknowledge, e.g. the `ids` of the `pihole_messages` data source",
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+			"acknowledged": {
+				Description: "IDs of the messages that were deleted, excluding messages that no longer existed",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+// resourceMessageAcknowledgementCreate deletes the configured messages
+func resourceMessageAcknowledgementCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	ids := make([]int, 0, d.Get("ids").(*schema.Set).Len())
+	for _, id := range d.Get("ids").(*schema.Set).List() {
+		ids = append(ids, id.(int))
+	}
+
+	sort.Ints(ids)
+
+	// Pi-hole does not reliably report deleting unknown messages as not found, so messages are compared before and after deleting them
+	existing, err := listMessageIDs(ctx, client.api)
+	if err != nil {
+		return diagFromErr(err, d)
+	}
+
+	hash := sha256.New()
+
+	for _, id := range ids {
+		hash.Write([]byte(strconv.Itoa(id)))
+		hash.Write([]byte{0})
+
+		if !existing[int64(id)] {
+			continue
+		}
+
+		if err := client.api.do(ctx, http.MethodDelete, "/api/info/messages/"+strconv.Itoa(id), nil, nil); err != nil && !isNotFoundError(err) {
+			return diagFromErr(err, d)
+		}
+	}
+
+	remaining, err := listMessageIDs(ctx, client.api)
+	if err != nil {
+		return diagFromErr(err, d)
+	}
+
+	acknowledged := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if existing[int64(id)] && !remaining[int64(id)] {
+			acknowledged = append(acknowledged, id)
+		}
+	}
+
+	if err := d.Set("acknowledged", acknowledged); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return diags
+}
+
+// listMessageIDs returns the IDs of the current FTL diagnosis messages
+func listMessageIDs(ctx context.Context, api *apiClient) (map[int64]bool, error) {
+	var res messagesResponse
+	if err := api.do(ctx, http.MethodGet, "/api/info/messages", nil, &res); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int64]bool, len(res.Messages))
+	for _, message := range res.Messages {
+		ids[message.ID] = true
+	}
+
+	return ids, nil
+}
+
+// resourceMessageAcknowledgementRead keeps the acknowledgement in state, deleted messages have no remote counterpart
+func resourceMessageAcknowledgementRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceMessageAcknowledgementDelete removes the acknowledgement from state
+func resourceMessageAcknowledgementDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
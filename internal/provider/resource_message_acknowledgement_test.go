@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccMessageAcknowledgement(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_message_acknowledgement" "missing" {
+					  ids = [999999]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_message_acknowledgement.missing", "ids.#", "1"),
+					resource.TestCheckResourceAttr("pihole_message_acknowledgement.missing", "acknowledged.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestMessageAcknowledgementCreateDiffsMessages(t *testing.T) {
+	var mu sync.Mutex
+	messages := map[int64]bool{1: true, 2: true}
+	var deleted []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodDelete {
+			id := strings.TrimPrefix(r.URL.Path, "/api/info/messages/")
+			deleted = append(deleted, id)
+
+			// FTL answers deletions of unknown messages with success as well
+			n, _ := strconv.ParseInt(id, 10, 64)
+			delete(messages, n)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		var res messagesResponse
+		for id := range messages {
+			res.Messages = append(res.Messages, diagnosisMessage{ID: id})
+		}
+
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	client := &Client{api: &apiClient{baseURL: ts.URL, http: ts.Client()}}
+
+	d := schema.TestResourceDataRaw(t, resourceMessageAcknowledgement().Schema, map[string]interface{}{
+		"ids": []interface{}{3, 2, 1},
+	})
+
+	if diags := resourceMessageAcknowledgementCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if acknowledged := d.Get("acknowledged").([]interface{}); !reflect.DeepEqual(acknowledged, []interface{}{1, 2}) {
+		t.Errorf("expected messages 1 and 2 to be acknowledged, got %v", acknowledged)
+	}
+
+	if !reflect.DeepEqual(deleted, []string{"1", "2"}) {
+		t.Errorf("expected only existing messages to be deleted, got %v", deleted)
+	}
+
+	if len(d.Id()) != 64 {
+		t.Errorf("expected the full SHA-256 hash as ID, got %q", d.Id())
+	}
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceSessionCleanup returns the API session cleanup Terraform resource management configuration
+func resourceSessionCleanup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Revokes the Pi-hole API sessions matching a user agent or age filter when created, freeing API seats leaked by automation. " +
+			"Sessions the provider process authenticates with are never revoked. Destroying the resource only removes it from state.",
+		CreateContext: resourceSessionCleanupCreate,
+		ReadContext:   resourceSessionCleanupRead,
+		DeleteContext: resourceSessionCleanupDelete,
+		Schema: map[string]*schema.Schema{
+			"user_agent_regex": {
+				Description:      "Revoke sessions whose user agent matches this regular expression, e.g. `terraform-provider-pihole/`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				AtLeastOneOf:     []string{"user_agent_regex", "older_than"},
+			},
+			"older_than": {
+				Description:      "Revoke sessions opened longer ago than this duration, e.g. `1h`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDuration,
+				AtLeastOneOf:     []string{"user_agent_regex", "older_than"},
+			},
+			"triggers": {
+				Description: "Arbitrary map of values that, when changed, runs the cleanup again",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"revoked": {
+				Description: "IDs of the revoked sessions",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+// sessionCleanupFilter selects the sessions revoked by a session cleanup, when both are set a session must match both
+type sessionCleanupFilter struct {
+	userAgent   *regexp.Regexp
+	loginBefore int64
+
+	// protected holds the IDs of the sessions used by the provider, which are never revoked
+	protected map[int64]bool
+}
+
+// match reports whether a session is revoked, the current session and the sessions used by the provider never are
+func (f sessionCleanupFilter) match(session apiSession) bool {
+	if session.CurrentSession || f.protected[session.ID] {
+		return false
+	}
+
+	if f.userAgent != nil && !f.userAgent.MatchString(session.UserAgent) {
+		return false
+	}
+
+	return f.loginBefore == 0 || session.LoginAt < f.loginBefore
+}
+
+// resourceSessionCleanupCreate revokes the sessions matching the filters
+func resourceSessionCleanupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	var filter sessionCleanupFilter
+
+	if value := d.Get("user_agent_regex").(string); value != "" {
+		var err error
+		if filter.userAgent, err = regexp.Compile(value); err != nil {
+			return diag.Errorf("invalid user_agent_regex: %s", err)
+		}
+	}
+
+	if value := d.Get("older_than").(string); value != "" {
+		olderThan, err := time.ParseDuration(value)
+		if err != nil {
+			return diag.Errorf("invalid older_than: %s", err)
+		}
+
+		filter.loginBefore = time.Now().Add(-olderThan).Unix()
+	}
+
+	filter.protected = usedSessionSlots(ctx, client.api)
+
+	sessions, err := listSessions(ctx, client.api)
+	if err != nil {
+		return diagFromErr(err, d)
+	}
+
+	revoked := make([]int64, 0, len(sessions))
+	for _, session := range sessions {
+		if !filter.match(session) {
+			continue
+		}
+
+		// Session IDs are slots reused by new sessions, so the session is looked up again right before revoking it
+		current, err := listSessions(ctx, client.api)
+		if err != nil {
+			return diagFromErr(err, d)
+		}
+
+		if !slices.ContainsFunc(current, func(s apiSession) bool { return sameSession(s, session) && filter.match(s) }) {
+			continue
+		}
+
+		if err := client.api.do(ctx, http.MethodDelete, "/api/auth/session/"+strconv.FormatInt(session.ID, 10), nil, nil); err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+
+			return diagFromErr(err, d)
+		}
+
+		revoked = append(revoked, session.ID)
+	}
+
+	if err := d.Set("revoked", revoked); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	return diags
+}
+
+// sameSession reports whether two listings of a session slot describe the same session
+func sameSession(a apiSession, b apiSession) bool {
+	return a.ID == b.ID && a.LoginAt == b.LoginAt && a.UserAgent == b.UserAgent
+}
+
+// usedSessionSlots returns the IDs of the sessions the provider process authenticates with.
+// Pi-hole lists sessions by slot rather than session ID, so each session ID is resolved by listing the sessions with it,
+// in which it is flagged as the current session. Session IDs that are no longer valid are skipped.
+func usedSessionSlots(ctx context.Context, api *apiClient) map[int64]bool {
+	slots := map[int64]bool{}
+
+	for _, sessionID := range usedSessionIDs(api.baseURL) {
+		sessions, err := listSessions(ctx, api.withSession(sessionID))
+		if err != nil {
+			continue
+		}
+
+		for _, session := range sessions {
+			if session.CurrentSession {
+				slots[session.ID] = true
+			}
+		}
+	}
+
+	return slots
+}
+
+// resourceSessionCleanupRead keeps the cleanup in state, revoked sessions have no remote counterpart
+func resourceSessionCleanupRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceSessionCleanupDelete removes the cleanup from state
+func resourceSessionCleanupDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
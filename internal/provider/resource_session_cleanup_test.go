@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccSessionCleanup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_session_cleanup" "none" {
+					  user_agent_regex = "^no-such-client/"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_session_cleanup.none", "revoked.#", "0"),
+				),
+			},
+			{
+				Config:      `resource "pihole_session_cleanup" "invalid" {}`,
+				ExpectError: regexp.MustCompile("one of `older_than,user_agent_regex` must be specified"),
+			},
+		},
+	})
+}
+
+func TestSessionCleanupFilterMatch(t *testing.T) {
+	provider := apiSession{ID: 1, LoginAt: 100, UserAgent: "Terraform/1.9.0 (+https://www.terraform.io) Terraform-Plugin-SDK/2.34.0 terraform-provider-pihole/1.0"}
+	browser := apiSession{ID: 2, LoginAt: 300, UserAgent: "Mozilla/5.0"}
+	current := apiSession{ID: 3, LoginAt: 50, UserAgent: "terraform-provider-pihole/1.0", CurrentSession: true}
+
+	cases := []struct {
+		name    string
+		filter  sessionCleanupFilter
+		session apiSession
+		want    bool
+	}{
+		{"user agent match", sessionCleanupFilter{userAgent: regexp.MustCompile("terraform-provider-pihole/")}, provider, true},
+		{"user agent mismatch", sessionCleanupFilter{userAgent: regexp.MustCompile("terraform-provider-pihole/")}, browser, false},
+		{"older", sessionCleanupFilter{loginBefore: 200}, provider, true},
+		{"newer", sessionCleanupFilter{loginBefore: 200}, browser, false},
+		{"both match", sessionCleanupFilter{userAgent: regexp.MustCompile("Mozilla"), loginBefore: 400}, browser, true},
+		{"both, age mismatch", sessionCleanupFilter{userAgent: regexp.MustCompile("Mozilla"), loginBefore: 200}, browser, false},
+		{"current session", sessionCleanupFilter{loginBefore: 200}, current, false},
+		{"provider session", sessionCleanupFilter{loginBefore: 200, protected: map[int64]bool{1: true}}, provider, false},
+	}
+
+	for _, c := range cases {
+		if got := c.filter.match(c.session); got != c.want {
+			t.Errorf("%s: match() = %t, want %t", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSessionCleanupCreateSkipsProviderAndReusedSessions(t *testing.T) {
+	const userAgent = "terraform-provider-pihole/1.0"
+
+	var mu sync.Mutex
+	var lists int
+	var deleted []int64
+
+	// Slots 0 and 1 are sessions of the provider, slot 3 is reused by a new login after the first listing
+	sessionIDs := map[string]int64{"current": 0, "alias": 1}
+	sessions := []apiSession{
+		{ID: 0, LoginAt: 10, UserAgent: userAgent},
+		{ID: 1, LoginAt: 10, UserAgent: userAgent},
+		{ID: 2, LoginAt: 10, UserAgent: userAgent},
+		{ID: 3, LoginAt: 10, UserAgent: userAgent},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodDelete {
+			id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/api/auth/session/"), 10, 64)
+			deleted = append(deleted, id)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		lists++
+
+		current, ok := sessionIDs[r.Header.Get("X-FTL-SID")]
+		listed := make([]apiSession, len(sessions))
+		for i, session := range sessions {
+			session.CurrentSession = ok && session.ID == current
+			listed[i] = session
+		}
+
+		if lists == 3 {
+			sessions[3].LoginAt = 20
+		}
+
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"sessions": listed}); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	useSession(ts.URL, "current")
+	useSession(ts.URL, "alias")
+
+	client := &Client{api: &apiClient{baseURL: ts.URL, http: ts.Client(), sessionID: "current"}}
+
+	d := schema.TestResourceDataRaw(t, resourceSessionCleanup().Schema, map[string]interface{}{
+		"user_agent_regex": "terraform-provider-pihole/",
+	})
+
+	if diags := resourceSessionCleanupCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if !reflect.DeepEqual(deleted, []int64{2}) {
+		t.Errorf("expected only session 2 to be revoked, got %v", deleted)
+	}
+
+	if revoked := d.Get("revoked").([]interface{}); !reflect.DeepEqual(revoked, []interface{}{2}) {
+		t.Errorf("expected revoked to be [2], got %v", revoked)
+	}
+}
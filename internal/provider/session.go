@@ -0,0 +1,391 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// session describes a Pi-hole API session as returned by the /api/auth endpoint
+type session struct {
+	Valid    bool   `json:"valid"`
+	SID      string `json:"sid"`
+	CSRF     string `json:"csrf"`
+	TOTP     bool   `json:"totp"`
+	Validity int    `json:"validity"`
+	Message  string `json:"message"`
+}
+
+// authResponse is the response body of the /api/auth endpoint
+type authResponse struct {
+	Session session `json:"session"`
+}
+
+// errTOTPRequired is returned when Pi-hole demands a TOTP code that was not configured
+var errTOTPRequired = errors.New("Pi-hole requires a TOTP code for this account")
+
+// errTOTPRejected is returned when Pi-hole rejects a login carrying a generated TOTP code
+var errTOTPRejected = errors.New("Pi-hole rejected the password or the generated TOTP code")
+
+// totpNow returns the time TOTP codes are generated for, replaced in tests
+var totpNow = time.Now
+
+// login creates a new Pi-hole API session with the passed password.
+// When totpSecret is set, the current TOTP code is generated and sent along with the password.
+// Pi-hole accepts every TOTP code only once, so when the code is rejected, e.g. as an earlier login of the same run used it,
+// the login is retried once with the code of the next period.
+func (c *apiClient) login(ctx context.Context, password string, totpSecret string) (*session, error) {
+	now := totpNow()
+
+	s, err := c.loginAt(ctx, password, totpSecret, now)
+	if !errors.Is(err, errTOTPRejected) {
+		return s, err
+	}
+
+	next := now.Truncate(totpPeriod).Add(totpPeriod)
+
+	timer := time.NewTimer(next.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+	}
+
+	s, err = c.loginAt(ctx, password, totpSecret, next)
+
+	var apiErr *apiError
+	if errors.Is(err, errTOTPRejected) && errors.As(err, &apiErr) {
+		return nil, fmt.Errorf("%w, also with the code of the next period. Check the credentials, totp_secret and the system clock, "+
+			"and whether other clients log in with the same TOTP secret, as every code is only accepted once: %w", errTOTPRejected, apiErr)
+	}
+
+	return s, err
+}
+
+// loginAt attempts a single login, with the TOTP code for the passed time when totpSecret is set
+func (c *apiClient) loginAt(ctx context.Context, password string, totpSecret string, at time.Time) (*session, error) {
+	body := map[string]interface{}{"password": password}
+
+	if totpSecret != "" {
+		code, err := generateTOTP(totpSecret, at)
+		if err != nil {
+			return nil, err
+		}
+
+		body["totp"] = code
+	}
+
+	var res authResponse
+	if err := c.do(ctx, http.MethodPost, "/api/auth", body, &res); err != nil {
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusUnauthorized) {
+			return nil, err
+		}
+
+		// Pi-hole reports whether two-factor authentication is enabled via the totp field of the session object
+		totp, totpErr := c.totpEnabled(ctx, apiErr)
+		if totpErr != nil || !totp {
+			return nil, err
+		}
+
+		if totpSecret == "" {
+			return nil, fmt.Errorf("%w: %w", errTOTPRequired, err)
+		}
+
+		if apiErr.Key == "unauthorized" {
+			return nil, fmt.Errorf("%w: %w", errTOTPRejected, err)
+		}
+
+		return nil, err
+	}
+
+	if res.Session.TOTP && totpSecret == "" && !res.Session.Valid {
+		return nil, fmt.Errorf("%w: %s", errTOTPRequired, res.Session.Message)
+	}
+
+	if !res.Session.Valid || res.Session.SID == "" {
+		return nil, fmt.Errorf("login failed: %s", res.Session.Message)
+	}
+
+	return &res.Session, nil
+}
+
+// totpEnabled reports whether Pi-hole requires a TOTP code, from the session object of a failed login
+// or, when the failure carries none, of an unauthenticated /api/auth request
+func (c *apiClient) totpEnabled(ctx context.Context, loginErr *apiError) (bool, error) {
+	var res authResponse
+	if json.Unmarshal(loginErr.body, &res) == nil && res.Session.TOTP {
+		return true, nil
+	}
+
+	err := c.withSession("").do(ctx, http.MethodGet, "/api/auth", nil, &res)
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+		err = json.Unmarshal(apiErr.body, &res)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return res.Session.TOTP, nil
+}
+
+// validateSession reports whether the passed session ID is still accepted by Pi-hole
+func (c *apiClient) validateSession(ctx context.Context, sessionID string) (bool, error) {
+	var res authResponse
+	if err := c.withSession(sessionID).do(ctx, http.MethodGet, "/api/auth", nil, &res); err != nil {
+		return false, err
+	}
+
+	return res.Session.Valid, nil
+}
+
+// logout ends the passed Pi-hole API session
+func (c *apiClient) logout(ctx context.Context, sessionID string) error {
+	return c.withSession(sessionID).do(ctx, http.MethodDelete, "/api/auth", nil, nil)
+}
+
+// cachedSession is the on-disk representation of a reusable session.
+// Pi-hole extends a session by its validity on every request, so the modification time of the cache file records
+// the last use of the session: it is refreshed when the session is reused and when the provider shuts down.
+// Sessions idle for longer than their validity are skipped, others are checked with validateSession before being reused.
+type cachedSession struct {
+	SID string `json:"sid"`
+
+	// Validity is the idle timeout of the session in seconds
+	Validity int `json:"validity"`
+}
+
+// sessionCache persists session IDs across provider runs, keyed by Pi-hole URL and credential
+type sessionCache struct {
+	dir string
+}
+
+// key derives the cache file name for a URL and credential pair without exposing the credential
+func (c sessionCache) key(url string, credential string) string {
+	return fmt.Sprintf("%x.json", sha256.Sum256([]byte(url+"\x00"+credential)))
+}
+
+// load returns the cached session ID, which may have expired on the Pi-hole side
+func (c sessionCache) load(url string, credential string) (string, bool) {
+	path := filepath.Join(c.dir, c.key(url, credential))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+
+	// Ignore cache files that are readable by anyone but the owner, they may have been tampered with
+	if info.Mode().Perm()&0o077 != 0 {
+		log.Printf("[WARN] Ignoring Pi-hole session cache file %q with permissions %v", path, info.Mode().Perm())
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	var cached cachedSession
+	if err := json.Unmarshal(data, &cached); err != nil || cached.SID == "" {
+		return "", false
+	}
+
+	if cached.Validity > 0 && time.Since(info.ModTime()) > time.Duration(cached.Validity)*time.Second {
+		return "", false
+	}
+
+	touchCachedSession(path)
+
+	return cached.SID, true
+}
+
+// store writes a session to the cache, readable only by the current user
+func (c sessionCache) store(url string, credential string, s *session) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create session cache directory %q: %w", c.dir, err)
+	}
+
+	data, err := json.Marshal(cachedSession{
+		SID:      s.SID,
+		Validity: s.Validity,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".session-*")
+	if err != nil {
+		return fmt.Errorf("failed to write session cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write session cache: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write session cache: %w", err)
+	}
+
+	path := filepath.Join(c.dir, c.key(url, credential))
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	touchCachedSession(path)
+
+	return nil
+}
+
+// usedCacheFiles holds the session cache files of the sessions used by this provider process
+var usedCacheFiles struct {
+	sync.Mutex
+	paths []string
+}
+
+// touchCachedSession records the use of a cached session by refreshing the modification time of its cache file,
+// which is refreshed again when the provider shuts down
+func touchCachedSession(path string) {
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		log.Printf("[WARN] Unable to update Pi-hole session cache file %q: %s", path, err)
+	}
+
+	usedCacheFiles.Lock()
+	defer usedCacheFiles.Unlock()
+
+	if !slices.Contains(usedCacheFiles.paths, path) {
+		usedCacheFiles.paths = append(usedCacheFiles.paths, path)
+	}
+}
+
+// defaultSessionCacheDir returns the directory used for the session cache when none is configured
+func defaultSessionCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "terraform-provider-pihole")
+}
+
+// usedSessions tracks the session IDs this provider process authenticates with, by Pi-hole URL,
+// whether they were configured, restored from the session cache or opened by a login
+var usedSessions struct {
+	sync.Mutex
+	sessionIDs map[string][]string
+}
+
+// useSession registers a session ID the provider authenticates with against a Pi-hole URL
+func useSession(url string, sessionID string) {
+	usedSessions.Lock()
+	defer usedSessions.Unlock()
+
+	if usedSessions.sessionIDs == nil {
+		usedSessions.sessionIDs = map[string][]string{}
+	}
+
+	if !slices.Contains(usedSessions.sessionIDs[url], sessionID) {
+		usedSessions.sessionIDs[url] = append(usedSessions.sessionIDs[url], sessionID)
+	}
+}
+
+// usedSessionIDs returns the session IDs the provider authenticates with against a Pi-hole URL
+func usedSessionIDs(url string) []string {
+	usedSessions.Lock()
+	defer usedSessions.Unlock()
+
+	return slices.Clone(usedSessions.sessionIDs[url])
+}
+
+// createdSessions tracks the sessions opened by this provider process that are not kept in the session cache
+var createdSessions struct {
+	sync.Mutex
+	sessions []trackedSession
+}
+
+type trackedSession struct {
+	api       *apiClient
+	sessionID string
+}
+
+// trackSession registers a session to be ended by LogoutSessions
+func trackSession(api *apiClient, sessionID string) {
+	createdSessions.Lock()
+	defer createdSessions.Unlock()
+
+	createdSessions.sessions = append(createdSessions.sessions, trackedSession{api: api, sessionID: sessionID})
+}
+
+// SessionLogoutTimeout bounds LogoutSessions. Terraform stops the plugin process about two seconds after
+// asking it to shut down, so logouts that have not completed by then would be cut off anyway.
+const SessionLogoutTimeout = 1500 * time.Millisecond
+
+// LogoutSessions ends every Pi-hole session opened by the provider that is not kept in the session cache,
+// and records the last use of the cached ones.
+// It is called once the plugin server stops so runs do not leave sessions occupying Pi-hole login slots.
+// Sessions are closed concurrently, those not closed before ctx is done are reported as skipped.
+func LogoutSessions(ctx context.Context) error {
+	// Cached sessions stay open, their cache files record they were in use until now
+	usedCacheFiles.Lock()
+	for _, path := range usedCacheFiles.paths {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+	}
+	usedCacheFiles.Unlock()
+
+	createdSessions.Lock()
+	sessions := createdSessions.sessions
+	createdSessions.sessions = nil
+	createdSessions.Unlock()
+
+	errs := make([]error, len(sessions))
+
+	var wg sync.WaitGroup
+	for i, s := range sessions {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[i] = s.api.logout(ctx, s.sessionID)
+		}()
+	}
+
+	wg.Wait()
+
+	var skipped int
+	var failures []error
+
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			skipped++
+		default:
+			failures = append(failures, err)
+		}
+	}
+
+	if skipped > 0 {
+		failures = append(failures, fmt.Errorf("skipped closing %d of %d sessions before the plugin shut down, "+
+			"they expire on their own after the Pi-hole session timeout; enable session_cache to reuse sessions instead: %w",
+			skipped, len(sessions), ctx.Err()))
+	}
+
+	return errors.Join(failures...)
+}
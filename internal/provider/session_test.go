@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionCache(t *testing.T) {
+	cache := sessionCache{dir: filepath.Join(t.TempDir(), "sessions")}
+
+	if _, ok := cache.load("http://pi.hole", "secret"); ok {
+		t.Fatal("expected empty cache to miss")
+	}
+
+	if err := cache.store("http://pi.hole", "secret", &session{SID: "abc", Validity: 300}); err != nil {
+		t.Fatalf("failed to store session: %s", err)
+	}
+
+	sessionID, ok := cache.load("http://pi.hole", "secret")
+	if !ok || sessionID != "abc" {
+		t.Fatalf("expected cached session abc, got %q", sessionID)
+	}
+
+	if _, ok := cache.load("http://pi.hole", "other"); ok {
+		t.Fatal("expected cache to be keyed by credential")
+	}
+
+	if _, ok := cache.load("http://other.hole", "secret"); ok {
+		t.Fatal("expected cache to be keyed by URL")
+	}
+
+	if strings.Contains(cache.key("http://pi.hole", "secret"), "secret") {
+		t.Fatal("expected cache key not to contain the credential")
+	}
+}
+
+func TestSessionCacheStoresValidity(t *testing.T) {
+	cache := sessionCache{dir: t.TempDir()}
+
+	if err := cache.store("http://pi.hole", "secret", &session{SID: "abc", Validity: 1800}); err != nil {
+		t.Fatalf("failed to store session: %s", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cache.dir, cache.key("http://pi.hole", "secret")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(data), `{"sid":"abc","validity":1800}`; got != want {
+		t.Fatalf("cache file = %s, want %s", got, want)
+	}
+
+	// A session last used within its validity is returned for validation and its use is recorded
+	path := filepath.Join(cache.dir, cache.key("http://pi.hole", "secret"))
+	recent := time.Now().Add(-10 * time.Minute)
+	if err := os.Chtimes(path, recent, recent); err != nil {
+		t.Fatal(err)
+	}
+
+	if sessionID, ok := cache.load("http://pi.hole", "secret"); !ok || sessionID != "abc" {
+		t.Fatalf("expected cached session abc, got %q", sessionID)
+	}
+
+	if info, err := os.Stat(path); err != nil || !info.ModTime().After(recent) {
+		t.Fatalf("expected loading the session to refresh its last use, got %v", info.ModTime())
+	}
+
+	// A session idle for longer than its validity has expired on the Pi-hole side
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if sessionID, ok := cache.load("http://pi.hole", "secret"); ok {
+		t.Fatalf("expected the expired session %q to be skipped", sessionID)
+	}
+}
+
+func TestLogoutSessions(t *testing.T) {
+	var mu sync.Mutex
+	var closed []string
+
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		closed = append(closed, r.Header.Get("X-FTL-SID"))
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(fast.Close)
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(slow.Close)
+
+	trackSession(&apiClient{baseURL: fast.URL, http: fast.Client()}, "one")
+	trackSession(&apiClient{baseURL: fast.URL, http: fast.Client()}, "two")
+
+	if err := LogoutSessions(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sort.Strings(closed)
+	if !slices.Equal(closed, []string{"one", "two"}) {
+		t.Fatalf("expected sessions one and two to be closed, got %v", closed)
+	}
+
+	trackSession(&apiClient{baseURL: slow.URL, http: slow.Client()}, "three")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := LogoutSessions(ctx)
+	if err == nil || !strings.Contains(err.Error(), "skipped closing 1 of 1 sessions") {
+		t.Fatalf("expected skipped session to be reported, got %v", err)
+	}
+
+	if err := LogoutSessions(context.Background()); err != nil {
+		t.Fatalf("expected skipped sessions not to be retried, got %s", err)
+	}
+}
+
+func TestSessionCachePermissions(t *testing.T) {
+	cache := sessionCache{dir: t.TempDir()}
+
+	if err := cache.store("http://pi.hole", "secret", &session{SID: "abc", Validity: 300}); err != nil {
+		t.Fatalf("failed to store session: %s", err)
+	}
+
+	path := filepath.Join(cache.dir, cache.key("http://pi.hole", "secret"))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("expected cache file permissions 0600, got %v", perm)
+	}
+
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cache.load("http://pi.hole", "secret"); ok {
+		t.Fatal("expected world readable cache file to be ignored")
+	}
+}
+
+// testTOTPNow makes login generate TOTP codes just before the end of a period, so a retry with the next code is immediate
+func testTOTPNow(t *testing.T) time.Time {
+	now := time.Now().Truncate(totpPeriod).Add(totpPeriod - 10*time.Millisecond)
+
+	totpNow = func() time.Time { return now }
+	t.Cleanup(func() { totpNow = time.Now })
+
+	return now
+}
+
+func TestLoginDetectsTOTP(t *testing.T) {
+	testTOTPNow(t)
+
+	cases := map[string]struct {
+		totpEnabled  bool
+		totpSecret   string
+		loginStatus  int
+		loginBody    string
+		wantRequired bool
+		wantError    string
+	}{
+		"missing code": {
+			totpEnabled:  true,
+			loginStatus:  http.StatusBadRequest,
+			loginBody:    `{"error":{"key":"bad_request","message":"No token found in JSON payload"}}`,
+			wantRequired: true,
+		},
+		"rejected code": {
+			totpEnabled: true,
+			totpSecret:  "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+			loginStatus: http.StatusUnauthorized,
+			loginBody:   `{"error":{"key":"unauthorized","message":"Unauthorized","hint":null}}`,
+			wantError:   "rejected the password or the generated TOTP code",
+		},
+		"wrong password": {
+			loginStatus: http.StatusUnauthorized,
+			loginBody:   `{"error":{"key":"unauthorized","message":"Unauthorized","hint":null}}`,
+			wantError:   "returned status 401",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusUnauthorized)
+					fmt.Fprintf(w, `{"session":{"valid":false,"totp":%t,"sid":null,"validity":-1}}`, c.totpEnabled)
+					return
+				}
+
+				w.WriteHeader(c.loginStatus)
+				fmt.Fprint(w, c.loginBody)
+			}))
+			t.Cleanup(ts.Close)
+
+			api := &apiClient{baseURL: ts.URL, http: ts.Client()}
+
+			_, err := api.login(context.Background(), "secret", c.totpSecret)
+			if err == nil {
+				t.Fatal("expected login to fail")
+			}
+
+			if got := errors.Is(err, errTOTPRequired); got != c.wantRequired {
+				t.Errorf("errors.Is(err, errTOTPRequired) = %t, want %t: %s", got, c.wantRequired, err)
+			}
+
+			if c.wantError != "" && !strings.Contains(err.Error(), c.wantError) {
+				t.Errorf("expected error to contain %q, got %s", c.wantError, err)
+			}
+		})
+	}
+}
+
+func TestLoginRetriesReusedTOTPCode(t *testing.T) {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	now := testTOTPNow(t)
+
+	used, err := generateTOTP(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var codes []int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TOTP int `json:"totp"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+
+		codes = append(codes, body.TOTP)
+
+		// The code of the current period was already used by an earlier login
+		if body.TOTP == used {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"key":"unauthorized","message":"Unauthorized","hint":null},"session":{"valid":false,"totp":true}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"session":{"valid":true,"totp":true,"sid":"abc","validity":300}}`)
+	}))
+	t.Cleanup(ts.Close)
+
+	api := &apiClient{baseURL: ts.URL, http: ts.Client()}
+
+	s, err := api.login(context.Background(), "secret", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.SID != "abc" {
+		t.Errorf("unexpected session %+v", s)
+	}
+
+	next, err := generateTOTP(secret, now.Truncate(totpPeriod).Add(totpPeriod))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(codes) != 2 || codes[0] != used || codes[1] != next {
+		t.Errorf("expected a retry with the code of the next period %d, got %v", next, codes)
+	}
+}
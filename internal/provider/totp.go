@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the time step used by Pi-hole for TOTP codes
+	totpPeriod = 30 * time.Second
+
+	// totpDigits is the number of digits in a Pi-hole TOTP code
+	totpDigits = 6
+)
+
+// generateTOTP returns the RFC 6238 code for the base32 encoded secret at the passed time
+func generateTOTP(secret string, at time.Time) (int, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	normalized = strings.TrimRight(normalized, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TOTP secret, expected a base32 encoded value: %w", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/int64(totpPeriod/time.Second)))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+
+	return int(code % modulo), nil
+}
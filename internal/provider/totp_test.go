@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateTOTP(t *testing.T) {
+	// RFC 6238 SHA-1 test vectors, truncated to six digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	cases := map[int64]int{
+		59:          287082,
+		1111111109:  81804,
+		1111111111:  50471,
+		1234567890:  5924,
+		2000000000:  279037,
+		20000000000: 353130,
+	}
+
+	for unix, expected := range cases {
+		code, err := generateTOTP(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if code != expected {
+			t.Errorf("at %d: expected %06d, got %06d", unix, expected, code)
+		}
+	}
+}
+
+func TestGenerateTOTPNormalizesSecret(t *testing.T) {
+	at := time.Unix(59, 0)
+
+	code, err := generateTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if code != 287082 {
+		t.Fatalf("expected 287082, got %06d", code)
+	}
+}
+
+func TestGenerateTOTPInvalidSecret(t *testing.T) {
+	if _, err := generateTOTP("not-base32!", time.Now()); err == nil {
+		t.Fatal("expected an error for an invalid secret")
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/idna"
+)
+
+// validateDomain validates that a string attribute is an RFC 1123 hostname, optionally fully qualified with a trailing dot.
+// Underscores are allowed in labels as in service names like _sip._tcp and hosts entries created outside of Terraform.
+// Internationalized domains are validated in their punycode form.
+func validateDomain(value interface{}, path cty.Path) diag.Diagnostics {
+	domain, ok := value.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", value)
+	}
+
+	if err := checkDomain(normalizeDomain(domain)); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid domain",
+				Detail:        fmt.Sprintf("%q is not a valid domain: %s.", domain, err),
+				AttributePath: path,
+			},
+		}
+	}
+
+	return nil
+}
+
+// checkDomain returns an error describing why the domain is not an RFC 1123 hostname allowing underscores
+func checkDomain(domain string) error {
+	name := strings.TrimSuffix(domain, ".")
+
+	if name == "" {
+		return fmt.Errorf("domain must not be empty")
+	}
+
+	if len(name) > 253 {
+		return fmt.Errorf("domain must be at most 253 characters long")
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return fmt.Errorf("domain must not contain empty labels")
+		}
+
+		if len(label) > 63 {
+			return fmt.Errorf("label %q must be at most 63 characters long", label)
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("label %q must not start or end with a hyphen", label)
+		}
+
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return fmt.Errorf("label %q contains the invalid character %q", label, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+// normalizeDomain returns the canonical form of a domain as stored in Pi-hole:
+// punycode for internationalized names, lowercase and without a trailing dot.
+// Domains which cannot be converted are only lowercased so validation can report them.
+func normalizeDomain(domain string) string {
+	name := strings.TrimSuffix(strings.TrimSpace(domain), ".")
+
+	if ascii, err := idna.Lookup.ToASCII(name); err == nil {
+		name = ascii
+	}
+
+	return strings.ToLower(name)
+}
+
+// normalizeDomainState is a schema.SchemaStateFunc storing domains in their canonical form
+func normalizeDomainState(value interface{}) string {
+	domain, _ := value.(string)
+
+	return normalizeDomain(domain)
+}
+
+// suppressEquivalentDomainDiff is a schema.SchemaDiffSuppressFunc ignoring differences in case, trailing dots and IDNA encoding
+func suppressEquivalentDomainDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return sameDomain(oldValue, newValue)
+}
+
+// sameDomain reports whether two domains are equal once normalized
+func sameDomain(a string, b string) bool {
+	return normalizeDomain(a) == normalizeDomain(b)
+}
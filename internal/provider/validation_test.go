@@ -0,0 +1,67 @@
+package provider
+
+import "testing"
+
+func TestCheckDomain(t *testing.T) {
+	valid := []string{
+		"foo.com",
+		"Foo.Example.com.",
+		"a-b.c0.local",
+		"localhost",
+		"bücher.example.com",
+		"foo_bar.com",
+		"_sip._tcp.example.com",
+	}
+
+	for _, domain := range valid {
+		if err := checkDomain(normalizeDomain(domain)); err != nil {
+			t.Errorf("expected %q to be valid, got %s", domain, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		".",
+		"foo..com",
+		"-foo.com",
+		"foo-.com",
+		"foo bar.com",
+		"a23456789012345678901234567890123456789012345678901234567890abcd.com",
+	}
+
+	for _, domain := range invalid {
+		if err := checkDomain(domain); err == nil {
+			t.Errorf("expected %q to be invalid", domain)
+		}
+	}
+}
+
+func TestSameDomain(t *testing.T) {
+	if !sameDomain("Foo.com.", "foo.com") {
+		t.Error("expected domains differing in case and trailing dot to match")
+	}
+
+	if !sameDomain("bücher.com", "xn--bcher-kva.com") {
+		t.Error("expected internationalized domain to match its punycode form")
+	}
+
+	if sameDomain("foo.com", "bar.com") {
+		t.Error("expected different domains not to match")
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	cases := map[string]string{
+		"foo.com":            "foo.com",
+		"Foo.Example.COM.":   "foo.example.com",
+		"bücher.example.com": "xn--bcher-kva.example.com",
+		"BÜCHER.example.com": "xn--bcher-kva.example.com",
+		"xn--bcher-kva.com.": "xn--bcher-kva.com",
+	}
+
+	for domain, expected := range cases {
+		if actual := normalizeDomain(domain); actual != expected {
+			t.Errorf("expected %q to normalize to %q, got %q", domain, expected, actual)
+		}
+	}
+}
@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/markjoyeuxcom/terraform-provider-pihole/internal/provider"
 )
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: provider.Provider,
+		GRPCProviderFunc: provider.ProviderServer,
 	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), provider.SessionLogoutTimeout)
+	defer cancel()
+
+	if err := provider.LogoutSessions(ctx); err != nil {
+		log.Printf("[WARN] Failed to close Pi-hole sessions: %s", err)
+	}
 }